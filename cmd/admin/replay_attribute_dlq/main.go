@@ -0,0 +1,35 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+
+	_ "github.com/lib/pq" // PostgreSQL driver
+
+	"github.com/s21platform/advert-service/internal/config"
+	new_attribute "github.com/s21platform/advert-service/internal/databus/new_attribute"
+	"github.com/s21platform/advert-service/internal/infra"
+	db "github.com/s21platform/advert-service/internal/repository/postgres"
+)
+
+// Повторно обрабатывает сообщения топика атрибутов, попавшие в attribute_dlq
+func main() {
+	batchSize := flag.Uint64("batch", 100, "number of DLQ entries read per query")
+	flag.Parse()
+
+	cfg := config.MustLoad()
+
+	dbRepo := db.New(cfg, infra.NewRecorder(nil, false))
+	defer dbRepo.Close()
+
+	handler := new_attribute.New(dbRepo, cfg.Consumer)
+
+	replayed, failed, err := handler.Replay(context.Background(), *batchSize)
+	if err != nil {
+		log.Fatalf("failed to replay attribute DLQ (replayed %d, failed %d): %v", replayed, failed, err)
+	}
+
+	fmt.Printf("Replayed %d attribute messages, %d still failing\n", replayed, failed)
+}
@@ -1,16 +1,23 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"net"
 
 	_ "github.com/lib/pq" // PostgreSQL driver
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc/filters"
 	"google.golang.org/grpc"
+	grpc_health "google.golang.org/grpc/health"
+	"google.golang.org/grpc/health/grpc_health_v1"
 
-	logger_lib "github.com/s21platform/logger-lib"
+	pkg "github.com/s21platform/metrics-lib/pkg"
 
+	"github.com/s21platform/advert-service/internal/auth"
 	"github.com/s21platform/advert-service/internal/config"
+	"github.com/s21platform/advert-service/internal/health"
 	"github.com/s21platform/advert-service/internal/infra"
 	db "github.com/s21platform/advert-service/internal/repository/postgres"
 	"github.com/s21platform/advert-service/internal/service"
@@ -18,28 +25,117 @@ import (
 )
 
 func main() {
+	if err := run(); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// run возвращает ошибку вместо log.Fatal, чтобы отложенные закрытия отработали до выхода с ненулевым кодом
+func run() error {
 	cfg := config.MustLoad()
 
-	logger := logger_lib.New(cfg.Logger.Host, cfg.Logger.Port, cfg.Service.Name, cfg.Platform.Env)
+	graphite, err := pkg.NewMetrics(cfg.Metrics.Host, cfg.Metrics.Port, cfg.Service.Name, cfg.Platform.Env)
+	if err != nil {
+		log.Println("failed to connect graphite: ", err)
+	}
+	metrics := infra.NewRecorder(graphite, cfg.Metrics.PrometheusPort != "")
+	defer metrics.Close()
+
+	shutdownTracing, err := infra.InitTracing(context.Background(), cfg.Tracing, cfg.Service.Name)
+	if err != nil {
+		return fmt.Errorf("failed to init tracing: %w", err)
+	}
+	defer func() {
+		ctx, cancel := context.WithTimeout(context.Background(), cfg.Service.ShutdownTimeout)
+		defer cancel()
+		if err := shutdownTracing(ctx); err != nil {
+			log.Println("failed to flush traces: ", err)
+		}
+	}()
+
+	logSink := infra.NewLogSink(cfg, metrics)
+	defer func() {
+		if !logSink.Close(cfg.Service.ShutdownTimeout) {
+			log.Printf("logs were not flushed in %s", cfg.Service.ShutdownTimeout)
+		}
+	}()
 
-	dbRepo := db.New(cfg)
+	dbRepo := db.New(cfg, metrics)
 	defer dbRepo.Close()
 
-	advertService := service.New(dbRepo)
-	server := grpc.NewServer(
+	authenticator, err := auth.New(cfg.Auth)
+	if err != nil {
+		return fmt.Errorf("failed to configure authentication: %w", err)
+	}
+	if cfg.Auth.AllowRawUUID {
+		log.Println("WARNING: raw uuid metadata is trusted without verification, do not use outside local development")
+	}
+
+	serverOpts := []grpc.ServerOption{
+		grpc.StatsHandler(otelgrpc.NewServerHandler(otelgrpc.WithFilter(filters.Not(filters.HealthCheck())))),
 		grpc.ChainUnaryInterceptor(
-			infra.AuthInterceptor,
-			infra.Logger(logger),
+			infra.RecoveryInterceptor(logSink, metrics),
+			infra.MetricsInterceptor(metrics),
+			infra.AuthInterceptor(authenticator),
+			infra.Logger(cfg, logSink),
+		),
+		grpc.ChainStreamInterceptor(
+			infra.StreamRecoveryInterceptor(logSink, metrics),
 		),
-	)
+	}
+	tlsOpt, err := infra.ServerTLS(cfg.Auth)
+	if err != nil {
+		return fmt.Errorf("failed to configure tls: %w", err)
+	}
+	if tlsOpt != nil {
+		serverOpts = append(serverOpts, tlsOpt)
+	}
+
+	advertService := service.New(dbRepo, service.NewRolePolicy(), service.NewValidator(cfg.Validation, cfg.Lifecycle.MaxAdvertLifetime), cfg.Lifecycle)
+	server := grpc.NewServer(serverOpts...)
 
 	advert.RegisterAdvertServiceServer(server, advertService)
 
+	checker := health.New(cfg.Health.Timeout)
+	checker.Add("postgres", dbRepo.Ping)
+
+	healthServer := grpc_health.NewServer()
+	grpc_health_v1.RegisterHealthServer(server, healthServer)
+
 	lis, err := net.Listen("tcp", fmt.Sprintf(":%s", cfg.Service.Port))
 	if err != nil {
-		log.Fatalf("cannot listen port: %s; Error: %v", cfg.Service.Port, err)
+		return fmt.Errorf("cannot listen port: %s; Error: %w", cfg.Service.Port, err)
 	}
-	if err = server.Serve(lis); err != nil {
-		log.Fatalf("cannot start grpc, port: %s; Error: %v", cfg.Service.Port, err)
+
+	ctx, stop := infra.SignalContext(context.Background())
+	defer stop()
+
+	go checker.Watch(ctx, healthServer, cfg.Health.Interval, advert.AdvertService_ServiceDesc.ServiceName)
+
+	if cfg.Metrics.PrometheusPort != "" {
+		go func() {
+			if err := infra.ServeHTTP(ctx, cfg.Metrics.PrometheusPort, metrics.Handler(), cfg.Health.Timeout); err != nil {
+				log.Println(err)
+			}
+		}()
 	}
+
+	serveErr := make(chan error, 1)
+	go func() {
+		serveErr <- server.Serve(lis)
+	}()
+
+	select {
+	case err = <-serveErr:
+		return fmt.Errorf("cannot start grpc, port: %s; Error: %w", cfg.Service.Port, err)
+	case <-ctx.Done():
+		log.Println("shutting down grpc server")
+		checker.Shutdown()
+		healthServer.Shutdown()
+		if !infra.GracefulStop(server, cfg.Service.ShutdownTimeout) {
+			log.Printf("grpc server did not stop in %s, in-flight RPCs were canceled", cfg.Service.ShutdownTimeout)
+		}
+	}
+
+	return nil
 }
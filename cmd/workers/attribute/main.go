@@ -5,12 +5,15 @@ import (
 	"fmt"
 	_ "github.com/lib/pq" // PostgreSQL driver
 	"log"
+	"net"
 
 	kafka_lib "github.com/s21platform/kafka-lib"
 	pkg "github.com/s21platform/metrics-lib/pkg"
 
 	"github.com/s21platform/advert-service/internal/config"
 	new_attribute "github.com/s21platform/advert-service/internal/databus/new_attribute"
+	"github.com/s21platform/advert-service/internal/health"
+	"github.com/s21platform/advert-service/internal/infra"
 	db "github.com/s21platform/advert-service/internal/repository/postgres"
 )
 
@@ -19,27 +22,60 @@ const attrConsGroup = "attribute_value_consumers"
 func main() {
 	cfg := config.MustLoad()
 
-	dbRepo := db.New(cfg)
-	defer dbRepo.Close()
-
-	metrics, err := pkg.NewMetrics(cfg.Metrics.Host, cfg.Metrics.Port, cfg.Service.Name, cfg.Platform.Env)
+	graphite, err := pkg.NewMetrics(cfg.Metrics.Host, cfg.Metrics.Port, cfg.Service.Name, cfg.Platform.Env)
 	if err != nil {
 		log.Println("failed to connect graphite: ", err)
 	}
+	metrics := infra.NewRecorder(graphite, false)
+	defer metrics.Close()
+
+	shutdownTracing, err := infra.InitTracing(context.Background(), cfg.Tracing, cfg.Service.Name+"-attribute-consumer")
+	if err != nil {
+		log.Fatalf("failed to init tracing: %v", err)
+	}
+	defer func() {
+		ctx, cancel := context.WithTimeout(context.Background(), cfg.Service.ShutdownTimeout)
+		defer cancel()
+		if err := shutdownTracing(ctx); err != nil {
+			log.Println("failed to flush traces: ", err)
+		}
+	}()
+
+	dbRepo := db.New(cfg, metrics)
+	defer dbRepo.Close()
 
 	kafkaConfig := kafka_lib.DefaultConsumerConfig(cfg.Kafka.Host, cfg.Kafka.Port, cfg.Kafka.SetAttributeTopic, attrConsGroup)
 
-	consumer, err := kafka_lib.NewConsumer(kafkaConfig, metrics)
+	consumer, err := infra.NewTracedConsumer(kafkaConfig, metrics)
 	if err != nil {
 		log.Fatal("failed to create kafka consumer: ", err)
 	}
 
-	ctx, cancel := context.WithCancel(context.Background())
-	defer cancel()
+	ctx, stop := infra.SignalContext(context.Background())
+	defer stop()
+
+	checker := health.New(cfg.Health.Timeout)
+	checker.Add("postgres", dbRepo.Ping)
+	checker.Add("kafka", health.TCPCheck(net.JoinHostPort(cfg.Kafka.Host, cfg.Kafka.Port)))
+	go func() {
+		if err := checker.Serve(ctx, cfg.Health.Port); err != nil {
+			log.Println(err)
+		}
+	}()
 
-	consumer.RegisterHandler(ctx, new_attribute.NewAttribute)
+	handler := new_attribute.New(dbRepo, cfg.Consumer)
+	drainer := &infra.Drainer{}
+	consumer.RegisterHandler(ctx, drainer.Wrap(handler.Handler))
 
 	fmt.Println("Consumer started")
 
 	<-ctx.Done()
+
+	log.Println("shutting down attribute consumer")
+	if !drainer.Drain(cfg.Service.ShutdownTimeout) {
+		log.Printf("attribute consumer did not drain in %s", cfg.Service.ShutdownTimeout)
+	}
+	if err := consumer.Close(); err != nil {
+		log.Println("failed to close kafka consumer: ", err)
+	}
 }
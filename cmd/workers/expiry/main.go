@@ -0,0 +1,79 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net"
+
+	_ "github.com/lib/pq" // PostgreSQL driver
+
+	kafka_lib "github.com/s21platform/kafka-lib"
+	pkg "github.com/s21platform/metrics-lib/pkg"
+
+	"github.com/s21platform/advert-service/internal/config"
+	"github.com/s21platform/advert-service/internal/health"
+	"github.com/s21platform/advert-service/internal/infra"
+	db "github.com/s21platform/advert-service/internal/repository/postgres"
+	"github.com/s21platform/advert-service/internal/workers/expiry"
+)
+
+func main() {
+	cfg := config.MustLoad()
+
+	graphite, err := pkg.NewMetrics(cfg.Metrics.Host, cfg.Metrics.Port, cfg.Service.Name, cfg.Platform.Env)
+	if err != nil {
+		log.Println("failed to connect graphite: ", err)
+	}
+	metrics := infra.NewRecorder(graphite, false)
+	defer metrics.Close()
+
+	shutdownTracing, err := infra.InitTracing(context.Background(), cfg.Tracing, cfg.Service.Name+"-expiry-worker")
+	if err != nil {
+		log.Fatalf("failed to init tracing: %v", err)
+	}
+	defer func() {
+		ctx, cancel := context.WithTimeout(context.Background(), cfg.Service.ShutdownTimeout)
+		defer cancel()
+		if err := shutdownTracing(ctx); err != nil {
+			log.Println("failed to flush traces: ", err)
+		}
+	}()
+
+	dbRepo := db.New(cfg, metrics)
+	defer dbRepo.Close()
+
+	producer := infra.NewTracedProducer(kafka_lib.DefaultProducerConfig(cfg.Kafka.Host, cfg.Kafka.Port, cfg.Kafka.AdvertExpiredTopic))
+	defer func() {
+		if err := producer.Close(); err != nil {
+			log.Println("failed to close kafka producer: ", err)
+		}
+	}()
+
+	reminderProducer := infra.NewTracedProducer(kafka_lib.DefaultProducerConfig(cfg.Kafka.Host, cfg.Kafka.Port, cfg.Kafka.ExpiryReminderTopic))
+	defer func() {
+		if err := reminderProducer.Close(); err != nil {
+			log.Println("failed to close kafka producer: ", err)
+		}
+	}()
+
+	ctx, stop := infra.SignalContext(context.Background())
+	defer stop()
+
+	checker := health.New(cfg.Health.Timeout)
+	checker.Add("postgres", dbRepo.Ping)
+	checker.Add("kafka", health.TCPCheck(net.JoinHostPort(cfg.Kafka.Host, cfg.Kafka.Port)))
+	go func() {
+		if err := checker.Serve(ctx, cfg.Health.Port); err != nil {
+			log.Println(err)
+		}
+	}()
+
+	worker := expiry.New(dbRepo, producer, reminderProducer, cfg.Expiry)
+
+	fmt.Println("Expiry worker started")
+
+	worker.Run(ctx)
+
+	log.Println("expiry worker stopped")
+}
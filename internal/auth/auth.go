@@ -0,0 +1,81 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/s21platform/advert-service/internal/config"
+	"github.com/s21platform/advert-service/internal/model"
+)
+
+var (
+	// ErrNoCredentials - запрос не несёт данных для этого способа аутентификации, пробуется следующий
+	ErrNoCredentials = errors.New("no credentials")
+	// ErrInvalidCredentials - данные есть, но не прошли проверку; следующие способы не пробуются
+	ErrInvalidCredentials = errors.New("invalid credentials")
+)
+
+// Authenticator определяет вызывающего по входящему gRPC-запросу
+type Authenticator interface {
+	Authenticate(ctx context.Context) (model.Caller, error)
+}
+
+// Chain пробует аутентификаторы по порядку, пока какой-нибудь не найдёт свои данные в запросе
+type Chain []Authenticator
+
+func (c Chain) Authenticate(ctx context.Context) (model.Caller, error) {
+	for _, a := range c {
+		caller, err := a.Authenticate(ctx)
+		if errors.Is(err, ErrNoCredentials) {
+			continue
+		}
+		return caller, err
+	}
+	return model.Caller{}, ErrNoCredentials
+}
+
+// New собирает цепочку из включённых в конфиге способов: JWT, mTLS, затем сырой uuid.
+// JWT идёт первым, чтобы шлюз с клиентским сертификатом мог передавать токен пользователя
+func New(cfg config.Auth) (Chain, error) {
+	var chain Chain
+
+	jwtAuth, err := NewJWT(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to configure jwt: %w", err)
+	}
+	if jwtAuth != nil {
+		chain = append(chain, jwtAuth)
+	}
+
+	if len(cfg.ServiceAccounts) > 0 {
+		mtls, err := NewMTLS(cfg.ServiceAccounts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to configure mtls: %w", err)
+		}
+		chain = append(chain, mtls)
+	}
+
+	if cfg.AllowRawUUID {
+		chain = append(chain, RawUUID{})
+	}
+
+	if len(chain) == 0 {
+		return nil, errors.New("no authentication method configured")
+	}
+	return chain, nil
+}
+
+// strongestRole выбирает из ролей токена самую широкую известную; без известных ролей вызывающий - user
+func strongestRole(roles []string) string {
+	role := model.RoleUser
+	for _, r := range roles {
+		switch r {
+		case model.RoleService:
+			return model.RoleService
+		case model.RoleModerator:
+			role = model.RoleModerator
+		}
+	}
+	return role
+}
@@ -0,0 +1,298 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+
+	"github.com/s21platform/advert-service/internal/config"
+	"github.com/s21platform/advert-service/internal/model"
+)
+
+const (
+	secret      = "test-secret"
+	userUUID    = "6f2b0c1e-8d4a-4b7e-9c3f-1a2b3c4d5e6f"
+	serviceUUID = "0b9f6d3a-2c1e-4f5a-8b7c-9d0e1f2a3b4c"
+)
+
+func bearerCtx(token string) context.Context {
+	return metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer "+token))
+}
+
+func sign(t *testing.T, method jwt.SigningMethod, key interface{}, kid string, claims jwt.MapClaims) string {
+	t.Helper()
+
+	token := jwt.NewWithClaims(method, claims)
+	if kid != "" {
+		token.Header["kid"] = kid
+	}
+	signed, err := token.SignedString(key)
+	assert.NoError(t, err)
+	return signed
+}
+
+func validClaims() jwt.MapClaims {
+	return jwt.MapClaims{
+		"uuid":  userUUID,
+		"roles": []string{"user", "moderator"},
+		"exp":   time.Now().Add(time.Hour).Unix(),
+	}
+}
+
+func TestJWT_HMAC(t *testing.T) {
+	t.Parallel()
+
+	a, err := NewJWT(config.Auth{JWTSecret: secret, UUIDClaim: "uuid", RolesClaim: "roles"})
+	assert.NoError(t, err)
+
+	t.Run("valid", func(t *testing.T) {
+		caller, err := a.Authenticate(bearerCtx(sign(t, jwt.SigningMethodHS256, []byte(secret), "", validClaims())))
+		assert.NoError(t, err)
+		assert.Equal(t, model.Caller{UUID: userUUID, Role: model.RoleModerator}, caller)
+	})
+
+	t.Run("no_roles", func(t *testing.T) {
+		claims := validClaims()
+		delete(claims, "roles")
+
+		caller, err := a.Authenticate(bearerCtx(sign(t, jwt.SigningMethodHS256, []byte(secret), "", claims)))
+		assert.NoError(t, err)
+		assert.Equal(t, model.RoleUser, caller.Role)
+	})
+
+	t.Run("wrong_secret", func(t *testing.T) {
+		_, err := a.Authenticate(bearerCtx(sign(t, jwt.SigningMethodHS256, []byte("other"), "", validClaims())))
+		assert.ErrorIs(t, err, ErrInvalidCredentials)
+	})
+
+	t.Run("expired", func(t *testing.T) {
+		claims := validClaims()
+		claims["exp"] = time.Now().Add(-time.Minute).Unix()
+
+		_, err := a.Authenticate(bearerCtx(sign(t, jwt.SigningMethodHS256, []byte(secret), "", claims)))
+		assert.ErrorIs(t, err, ErrInvalidCredentials)
+	})
+
+	t.Run("no_exp", func(t *testing.T) {
+		claims := validClaims()
+		delete(claims, "exp")
+
+		_, err := a.Authenticate(bearerCtx(sign(t, jwt.SigningMethodHS256, []byte(secret), "", claims)))
+		assert.ErrorIs(t, err, ErrInvalidCredentials)
+	})
+
+	t.Run("no_uuid", func(t *testing.T) {
+		claims := validClaims()
+		delete(claims, "uuid")
+
+		_, err := a.Authenticate(bearerCtx(sign(t, jwt.SigningMethodHS256, []byte(secret), "", claims)))
+		assert.ErrorIs(t, err, ErrInvalidCredentials)
+	})
+
+	t.Run("uuid_not_uuid", func(t *testing.T) {
+		claims := validClaims()
+		claims["uuid"] = "moderation-service"
+
+		_, err := a.Authenticate(bearerCtx(sign(t, jwt.SigningMethodHS256, []byte(secret), "", claims)))
+		assert.ErrorIs(t, err, ErrInvalidCredentials)
+	})
+
+	t.Run("unsigned", func(t *testing.T) {
+		token := sign(t, jwt.SigningMethodNone, jwt.UnsafeAllowNoneSignatureType, "", validClaims())
+
+		_, err := a.Authenticate(bearerCtx(token))
+		assert.ErrorIs(t, err, ErrInvalidCredentials)
+	})
+
+	t.Run("not_bearer", func(t *testing.T) {
+		ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Basic abc"))
+
+		_, err := a.Authenticate(ctx)
+		assert.ErrorIs(t, err, ErrInvalidCredentials)
+	})
+
+	t.Run("no_header", func(t *testing.T) {
+		_, err := a.Authenticate(context.Background())
+		assert.ErrorIs(t, err, ErrNoCredentials)
+	})
+}
+
+func TestJWT_JWKS(t *testing.T) {
+	t.Parallel()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+
+	jwksFile := writeJWKS(t, "key-1", &key.PublicKey)
+	a, err := NewJWT(config.Auth{JWKSFile: jwksFile, JWTIssuer: "auth-service", UUIDClaim: "uuid", RolesClaim: "roles"})
+	assert.NoError(t, err)
+
+	claims := validClaims()
+	claims["iss"] = "auth-service"
+	claims["roles"] = "service"
+
+	t.Run("valid", func(t *testing.T) {
+		caller, err := a.Authenticate(bearerCtx(sign(t, jwt.SigningMethodRS256, key, "key-1", claims)))
+		assert.NoError(t, err)
+		assert.Equal(t, model.Caller{UUID: userUUID, Role: model.RoleService}, caller)
+	})
+
+	t.Run("unknown_kid", func(t *testing.T) {
+		_, err := a.Authenticate(bearerCtx(sign(t, jwt.SigningMethodRS256, key, "key-2", claims)))
+		assert.ErrorIs(t, err, ErrInvalidCredentials)
+	})
+
+	t.Run("wrong_issuer", func(t *testing.T) {
+		other := validClaims()
+		other["iss"] = "someone-else"
+
+		_, err := a.Authenticate(bearerCtx(sign(t, jwt.SigningMethodRS256, key, "key-1", other)))
+		assert.ErrorIs(t, err, ErrInvalidCredentials)
+	})
+
+	t.Run("hmac_not_configured", func(t *testing.T) {
+		_, err := a.Authenticate(bearerCtx(sign(t, jwt.SigningMethodHS256, []byte(secret), "key-1", claims)))
+		assert.ErrorIs(t, err, ErrInvalidCredentials)
+	})
+}
+
+func TestNewJWT_NotConfigured(t *testing.T) {
+	t.Parallel()
+
+	a, err := NewJWT(config.Auth{})
+	assert.NoError(t, err)
+	assert.Nil(t, a)
+}
+
+func TestMTLS(t *testing.T) {
+	t.Parallel()
+
+	a, err := NewMTLS(map[string]string{"billing-service": serviceUUID})
+	assert.NoError(t, err)
+
+	tlsCtx := func(cn string) context.Context {
+		cert := &x509.Certificate{Subject: pkix.Name{CommonName: cn}}
+		return peer.NewContext(context.Background(), &peer.Peer{
+			AuthInfo: credentials.TLSInfo{State: tls.ConnectionState{VerifiedChains: [][]*x509.Certificate{{cert}}}},
+		})
+	}
+
+	t.Run("service_account", func(t *testing.T) {
+		caller, err := a.Authenticate(tlsCtx("billing-service"))
+		assert.NoError(t, err)
+		// uuid аккаунта, а не CN, попадает в banned_by и другие uuid-колонки
+		assert.Equal(t, model.Caller{UUID: serviceUUID, Role: model.RoleService}, caller)
+	})
+
+	t.Run("unknown_account", func(t *testing.T) {
+		_, err := a.Authenticate(tlsCtx("other-service"))
+		assert.ErrorIs(t, err, ErrInvalidCredentials)
+	})
+
+	t.Run("no_client_certificate", func(t *testing.T) {
+		ctx := peer.NewContext(context.Background(), &peer.Peer{AuthInfo: credentials.TLSInfo{}})
+
+		_, err := a.Authenticate(ctx)
+		assert.ErrorIs(t, err, ErrNoCredentials)
+	})
+}
+
+func TestNewMTLS_InvalidUUID(t *testing.T) {
+	t.Parallel()
+
+	_, err := NewMTLS(map[string]string{"billing-service": "billing-service"})
+	assert.Error(t, err)
+}
+
+func TestRawUUID(t *testing.T) {
+	t.Parallel()
+
+	t.Run("default_role", func(t *testing.T) {
+		ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("uuid", "user-uuid"))
+
+		caller, err := RawUUID{}.Authenticate(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, model.Caller{UUID: "user-uuid", Role: model.RoleUser}, caller)
+	})
+
+	t.Run("unknown_role", func(t *testing.T) {
+		ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("uuid", "user-uuid", "role", "admin"))
+
+		_, err := RawUUID{}.Authenticate(ctx)
+		assert.ErrorIs(t, err, model.ErrPermissionDenied)
+	})
+}
+
+func TestNew(t *testing.T) {
+	t.Parallel()
+
+	t.Run("nothing_configured", func(t *testing.T) {
+		_, err := New(config.Auth{})
+		assert.Error(t, err)
+	})
+
+	t.Run("raw_uuid_only_behind_flag", func(t *testing.T) {
+		chain, err := New(config.Auth{JWTSecret: secret, UUIDClaim: "uuid", RolesClaim: "roles"})
+		assert.NoError(t, err)
+
+		ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("uuid", "user-uuid"))
+		_, err = chain.Authenticate(ctx)
+		assert.ErrorIs(t, err, ErrNoCredentials)
+	})
+
+	t.Run("falls_through_to_raw_uuid", func(t *testing.T) {
+		chain, err := New(config.Auth{JWTSecret: secret, AllowRawUUID: true, UUIDClaim: "uuid", RolesClaim: "roles"})
+		assert.NoError(t, err)
+
+		ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("uuid", "user-uuid"))
+		caller, err := chain.Authenticate(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, "user-uuid", caller.UUID)
+	})
+
+	t.Run("invalid_token_does_not_fall_through", func(t *testing.T) {
+		chain, err := New(config.Auth{JWTSecret: secret, AllowRawUUID: true, UUIDClaim: "uuid", RolesClaim: "roles"})
+		assert.NoError(t, err)
+
+		ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(
+			"authorization", "Bearer "+sign(t, jwt.SigningMethodHS256, []byte("other"), "", validClaims()),
+			"uuid", "user-uuid",
+		))
+		_, err = chain.Authenticate(ctx)
+		assert.ErrorIs(t, err, ErrInvalidCredentials)
+	})
+}
+
+func writeJWKS(t *testing.T, kid string, key *rsa.PublicKey) string {
+	t.Helper()
+
+	data, err := json.Marshal(map[string]interface{}{
+		"keys": []map[string]string{{
+			"kty": "RSA",
+			"kid": kid,
+			"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+			"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+		}},
+	})
+	assert.NoError(t, err)
+
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	assert.NoError(t, os.WriteFile(path, data, 0o600))
+	return path
+}
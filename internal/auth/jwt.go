@@ -0,0 +1,198 @@
+package auth
+
+import (
+	"context"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"strings"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/s21platform/advert-service/internal/config"
+	"github.com/s21platform/advert-service/internal/model"
+)
+
+const authorizationHeader = "authorization"
+
+// JWT проверяет подписанный токен из заголовка authorization: Bearer <token>
+type JWT struct {
+	parser     *jwt.Parser
+	secret     []byte
+	rsaKeys    map[string]*rsa.PublicKey
+	uuidClaim  string
+	rolesClaim string
+}
+
+// NewJWT возвращает nil, если в конфиге нет ни одного ключа
+func NewJWT(cfg config.Auth) (*JWT, error) {
+	a := &JWT{
+		rsaKeys:    make(map[string]*rsa.PublicKey),
+		uuidClaim:  cfg.UUIDClaim,
+		rolesClaim: cfg.RolesClaim,
+	}
+
+	// алгоритмы ограничены теми, для которых есть ключи, иначе токен мог бы выбрать алгоритм сам
+	var methods []string
+	if cfg.JWTSecret != "" {
+		a.secret = []byte(cfg.JWTSecret)
+		methods = append(methods, jwt.SigningMethodHS256.Alg())
+	}
+	if cfg.JWTPublicKeyFile != "" {
+		pemData, err := os.ReadFile(cfg.JWTPublicKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read public key: %w", err)
+		}
+		key, err := jwt.ParseRSAPublicKeyFromPEM(pemData)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse public key: %w", err)
+		}
+		a.rsaKeys[""] = key
+	}
+	if cfg.JWKSFile != "" {
+		keys, err := loadJWKS(cfg.JWKSFile)
+		if err != nil {
+			return nil, err
+		}
+		for kid, key := range keys {
+			a.rsaKeys[kid] = key
+		}
+	}
+	if len(a.rsaKeys) > 0 {
+		methods = append(methods, jwt.SigningMethodRS256.Alg())
+	}
+
+	if len(methods) == 0 {
+		return nil, nil
+	}
+
+	opts := []jwt.ParserOption{jwt.WithValidMethods(methods), jwt.WithExpirationRequired()}
+	if cfg.JWTIssuer != "" {
+		opts = append(opts, jwt.WithIssuer(cfg.JWTIssuer))
+	}
+	if cfg.JWTAudience != "" {
+		opts = append(opts, jwt.WithAudience(cfg.JWTAudience))
+	}
+	a.parser = jwt.NewParser(opts...)
+
+	return a, nil
+}
+
+func (a *JWT) Authenticate(ctx context.Context) (model.Caller, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	values := md.Get(authorizationHeader)
+	if len(values) == 0 {
+		return model.Caller{}, ErrNoCredentials
+	}
+	if len(values) > 1 {
+		return model.Caller{}, fmt.Errorf("%w: more than one authorization header", ErrInvalidCredentials)
+	}
+
+	raw, ok := strings.CutPrefix(values[0], "Bearer ")
+	if !ok {
+		return model.Caller{}, fmt.Errorf("%w: authorization is not a bearer token", ErrInvalidCredentials)
+	}
+
+	claims := jwt.MapClaims{}
+	if _, err := a.parser.ParseWithClaims(raw, claims, a.key); err != nil {
+		return model.Caller{}, fmt.Errorf("%w: %v", ErrInvalidCredentials, err)
+	}
+
+	// uuid вызывающего пишется в uuid-колонки, поэтому некорректный отсекается здесь, а не ошибкой postgres
+	id, _ := claims[a.uuidClaim].(string)
+	if _, err := uuid.Parse(id); err != nil {
+		return model.Caller{}, fmt.Errorf("%w: %s claim is not a uuid", ErrInvalidCredentials, a.uuidClaim)
+	}
+
+	return model.Caller{UUID: id, Role: strongestRole(stringsClaim(claims[a.rolesClaim]))}, nil
+}
+
+func (a *JWT) key(token *jwt.Token) (interface{}, error) {
+	switch token.Method.(type) {
+	case *jwt.SigningMethodHMAC:
+		return a.secret, nil
+	case *jwt.SigningMethodRSA:
+		kid, _ := token.Header["kid"].(string)
+		if key, ok := a.rsaKeys[kid]; ok {
+			return key, nil
+		}
+		// токен без kid подходит, только если ключ единственный
+		if kid == "" && len(a.rsaKeys) == 1 {
+			for _, key := range a.rsaKeys {
+				return key, nil
+			}
+		}
+		return nil, fmt.Errorf("unknown key id %q", kid)
+	}
+	return nil, fmt.Errorf("unexpected signing method %s", token.Method.Alg())
+}
+
+// stringsClaim принимает как массив строк, так и одну строку
+func stringsClaim(v interface{}) []string {
+	switch v := v.(type) {
+	case string:
+		return []string{v}
+	case []interface{}:
+		res := make([]string, 0, len(v))
+		for _, item := range v {
+			if s, ok := item.(string); ok {
+				res = append(res, s)
+			}
+		}
+		return res
+	}
+	return nil
+}
+
+type jwks struct {
+	Keys []struct {
+		Kty string `json:"kty"`
+		Kid string `json:"kid"`
+		N   string `json:"n"`
+		E   string `json:"e"`
+	} `json:"keys"`
+}
+
+// loadJWKS читает RSA-ключи из JWKS-файла по kid; ключи других типов пропускаются
+func loadJWKS(path string) (map[string]*rsa.PublicKey, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read jwks: %w", err)
+	}
+
+	var set jwks
+	if err := json.Unmarshal(data, &set); err != nil {
+		return nil, fmt.Errorf("failed to parse jwks: %w", err)
+	}
+
+	keys := make(map[string]*rsa.PublicKey, len(set.Keys))
+	for _, k := range set.Keys {
+		if k.Kty != "RSA" {
+			continue
+		}
+		n, err := base64.RawURLEncoding.DecodeString(k.N)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode modulus of key %q: %w", k.Kid, err)
+		}
+		e, err := base64.RawURLEncoding.DecodeString(k.E)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode exponent of key %q: %w", k.Kid, err)
+		}
+		exponent := new(big.Int).SetBytes(e)
+		if !exponent.IsInt64() || exponent.Int64() > 1<<31-1 {
+			return nil, fmt.Errorf("exponent of key %q is too large", k.Kid)
+		}
+		keys[k.Kid] = &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(exponent.Int64())}
+	}
+
+	if len(keys) == 0 {
+		return nil, errors.New("jwks has no rsa keys")
+	}
+	return keys, nil
+}
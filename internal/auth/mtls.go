@@ -0,0 +1,49 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+
+	"github.com/s21platform/advert-service/internal/model"
+)
+
+// MTLS узнаёт сервисный аккаунт по CN проверенного клиентского сертификата.
+// Вызывающим становится uuid аккаунта: он пишется в uuid-колонки вроде banned_by и edited_by
+type MTLS struct {
+	accounts map[string]string
+}
+
+// NewMTLS принимает соответствие CN -> uuid и проверяет, что все uuid корректны
+func NewMTLS(accounts map[string]string) (*MTLS, error) {
+	a := &MTLS{accounts: make(map[string]string, len(accounts))}
+	for name, id := range accounts {
+		if _, err := uuid.Parse(id); err != nil {
+			return nil, fmt.Errorf("service account %q has invalid uuid %q: %w", name, id, err)
+		}
+		a.accounts[name] = id
+	}
+	return a, nil
+}
+
+func (a *MTLS) Authenticate(ctx context.Context) (model.Caller, error) {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return model.Caller{}, ErrNoCredentials
+	}
+	tlsInfo, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok || len(tlsInfo.State.VerifiedChains) == 0 || len(tlsInfo.State.VerifiedChains[0]) == 0 {
+		return model.Caller{}, ErrNoCredentials
+	}
+
+	name := tlsInfo.State.VerifiedChains[0][0].Subject.CommonName
+	id, ok := a.accounts[name]
+	if !ok {
+		return model.Caller{}, fmt.Errorf("%w: %q is not a service account", ErrInvalidCredentials, name)
+	}
+
+	return model.Caller{UUID: id, Role: model.RoleService}, nil
+}
@@ -2,22 +2,32 @@ package config
 
 import (
 	"log"
+	"time"
 
 	"github.com/ilyakaznacheev/cleanenv"
 )
 
 type Config struct {
-	Service  Service
-	Postgres Postgres
-	Metrics  Metrics
-	Logger   Logger
-	Kafka    Kafka
-	Platform Platform
+	Service    Service
+	Postgres   Postgres
+	Metrics    Metrics
+	Logger     Logger
+	Kafka      Kafka
+	Platform   Platform
+	Validation Validation
+	Expiry     Expiry
+	Lifecycle  Lifecycle
+	Outbox     Outbox
+	Consumer   Consumer
+	Health     Health
+	Tracing    Tracing
+	Auth       Auth
 }
 
 type Service struct {
-	Port string `env:"ADVERT_SERVICE_PORT"`
-	Name string `env:"ADVERT_SERVICE_NAME"`
+	Port            string        `env:"ADVERT_SERVICE_PORT"`
+	Name            string        `env:"ADVERT_SERVICE_NAME"`
+	ShutdownTimeout time.Duration `env:"ADVERT_SERVICE_SHUTDOWN_TIMEOUT" env-default:"15s"`
 }
 
 type Postgres struct {
@@ -31,23 +41,101 @@ type Postgres struct {
 type Metrics struct {
 	Host string `env:"GRAFANA_HOST"`
 	Port int    `env:"GRAFANA_PORT"`
+	// PrometheusPort включает /metrics в формате prometheus, если задан
+	PrometheusPort string `env:"ADVERT_SERVICE_PROMETHEUS_PORT"`
 }
 
 type Logger struct {
 	Host string `env:"ADVERT_SERVICE_LOGGER_HOST"`
 	Port string `env:"ADVERT_SERVICE_LOGGER_PORT"`
+	// Level: debug пишет тела запросов целиком, на остальных уровнях текст объявлений скрывается
+	Level string `env:"ADVERT_SERVICE_LOG_LEVEL" env-default:"info"`
+	// BufferSize - очередь записей для loki; при переполнении записи отбрасываются
+	BufferSize int `env:"ADVERT_SERVICE_LOG_BUFFER_SIZE" env-default:"1024"`
+	Workers    int `env:"ADVERT_SERVICE_LOG_WORKERS" env-default:"4"`
 }
 
 type Kafka struct {
-	Host              string `env:"KAFKA_HOST"`
-	Port              string `env:"KAFKA_PORT"`
-	SetAttributeTopic string `env:"STAFF_SET_ATTRIBUTE"`
+	Host                string `env:"KAFKA_HOST"`
+	Port                string `env:"KAFKA_PORT"`
+	SetAttributeTopic   string `env:"STAFF_SET_ATTRIBUTE"`
+	AdvertExpiredTopic  string `env:"ADVERT_EXPIRED"`
+	ExpiryReminderTopic string `env:"ADVERT_EXPIRY_REMINDER"`
+	AdvertEventsTopic   string `env:"ADVERT_EVENTS"`
 }
 
 type Platform struct {
 	Env string `env:"ENV"`
 }
 
+type Validation struct {
+	TitleMaxLen int `env:"ADVERT_SERVICE_TITLE_MAX_LEN" env-default:"120"`
+	TextMaxLen  int `env:"ADVERT_SERVICE_TEXT_MAX_LEN" env-default:"4000"`
+	MaxOsCount  int `env:"ADVERT_SERVICE_MAX_OS_COUNT" env-default:"32"`
+}
+
+type Expiry struct {
+	Interval          time.Duration   `env:"ADVERT_EXPIRY_INTERVAL" env-default:"1m"`
+	BatchSize         uint64          `env:"ADVERT_EXPIRY_BATCH_SIZE" env-default:"100"`
+	ReminderLeadTimes []time.Duration `env:"ADVERT_EXPIRY_REMINDER_LEAD_TIMES" env-default:"72h,24h"`
+}
+
+type Lifecycle struct {
+	PauseExtendsExpiry bool          `env:"ADVERT_PAUSE_EXTENDS_EXPIRY" env-default:"true"`
+	RestoreGraceWindow time.Duration `env:"ADVERT_RESTORE_GRACE_WINDOW" env-default:"72h"`
+	RenewalGracePeriod time.Duration `env:"ADVERT_RENEWAL_GRACE_PERIOD" env-default:"168h"`
+	// MaxAdvertLifetime ограничивает срок показа от публикации: и при создании, и при продлении
+	MaxAdvertLifetime time.Duration `env:"ADVERT_MAX_LIFETIME" env-default:"4320h"`
+}
+
+type Outbox struct {
+	Interval    time.Duration `env:"ADVERT_OUTBOX_INTERVAL" env-default:"1s"`
+	BatchSize   uint64        `env:"ADVERT_OUTBOX_BATCH_SIZE" env-default:"100"`
+	BaseBackoff time.Duration `env:"ADVERT_OUTBOX_BASE_BACKOFF" env-default:"1s"`
+	MaxBackoff  time.Duration `env:"ADVERT_OUTBOX_MAX_BACKOFF" env-default:"5m"`
+}
+
+type Consumer struct {
+	MaxAttempts int           `env:"ATTRIBUTE_CONSUMER_MAX_ATTEMPTS" env-default:"5"`
+	BaseBackoff time.Duration `env:"ATTRIBUTE_CONSUMER_BASE_BACKOFF" env-default:"200ms"`
+	MaxBackoff  time.Duration `env:"ATTRIBUTE_CONSUMER_MAX_BACKOFF" env-default:"10s"`
+}
+
+type Health struct {
+	Port     string        `env:"ADVERT_WORKER_HEALTH_PORT" env-default:"8081"`
+	Interval time.Duration `env:"ADVERT_HEALTH_INTERVAL" env-default:"5s"`
+	Timeout  time.Duration `env:"ADVERT_HEALTH_TIMEOUT" env-default:"2s"`
+}
+
+type Tracing struct {
+	// Exporter: otlp, stdout для локального запуска или none
+	Exporter    string  `env:"ADVERT_TRACING_EXPORTER" env-default:"none"`
+	Endpoint    string  `env:"ADVERT_TRACING_OTLP_ENDPOINT" env-default:"localhost:4317"`
+	Insecure    bool    `env:"ADVERT_TRACING_OTLP_INSECURE" env-default:"true"`
+	SampleRatio float64 `env:"ADVERT_TRACING_SAMPLE_RATIO" env-default:"1"`
+}
+
+type Auth struct {
+	// Ключи JWT: общий секрет HS256, публичный RSA-ключ в PEM или локальный JWKS-файл для RS256
+	JWTSecret        string `env:"ADVERT_AUTH_JWT_SECRET"`
+	JWTPublicKeyFile string `env:"ADVERT_AUTH_JWT_PUBLIC_KEY_FILE"`
+	JWKSFile         string `env:"ADVERT_AUTH_JWKS_FILE"`
+	JWTIssuer        string `env:"ADVERT_AUTH_JWT_ISSUER"`
+	JWTAudience      string `env:"ADVERT_AUTH_JWT_AUDIENCE"`
+	UUIDClaim        string `env:"ADVERT_AUTH_UUID_CLAIM" env-default:"uuid"`
+	RolesClaim       string `env:"ADVERT_AUTH_ROLES_CLAIM" env-default:"roles"`
+
+	// mTLS: сервер принимает клиентские сертификаты, подписанные ClientCAFile.
+	// ServiceAccounts сопоставляет CN сертификата uuid сервисного аккаунта: "moderation-service:<uuid>,..."
+	TLSCertFile     string            `env:"ADVERT_AUTH_TLS_CERT_FILE"`
+	TLSKeyFile      string            `env:"ADVERT_AUTH_TLS_KEY_FILE"`
+	ClientCAFile    string            `env:"ADVERT_AUTH_CLIENT_CA_FILE"`
+	ServiceAccounts map[string]string `env:"ADVERT_AUTH_SERVICE_ACCOUNTS"`
+
+	// AllowRawUUID - доверять метаданным uuid и role без проверки; только для локальной разработки
+	AllowRawUUID bool `env:"ADVERT_AUTH_ALLOW_RAW_UUID" env-default:"false"`
+}
+
 func MustLoad() *Config {
 	cfg := &Config{}
 	err := cleanenv.ReadEnv(cfg)
@@ -3,6 +3,8 @@ package config
 type key string
 
 const (
-	KeyUUID   = key("uuid")
-	KeyLogger = key("logger")
+	KeyUUID    = key("uuid")
+	KeyRole    = key("role")
+	KeyLogger  = key("logger")
+	KeyMetrics = key("metrics")
 )
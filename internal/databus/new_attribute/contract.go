@@ -0,0 +1,15 @@
+//go:generate mockgen -destination=mock_contract_test.go -package=${GOPACKAGE} -source=contract.go
+package new_attribute
+
+import (
+	"context"
+
+	"github.com/s21platform/advert-service/internal/model"
+)
+
+type DBRepo interface {
+	UpsertAttributeValue(ctx context.Context, value model.AttributeValue) error
+	SaveDeadLetter(ctx context.Context, payload []byte, reason string, attempts int) error
+	GetDeadLetters(ctx context.Context, afterID int64, limit uint64) ([]model.DeadLetter, error)
+	ResolveDeadLetter(ctx context.Context, ID int64, replayErr error) error
+}
@@ -3,17 +3,116 @@ package new_attribute
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"log"
+	"time"
+
+	"github.com/s21platform/advert-service/internal/config"
+	"github.com/s21platform/advert-service/internal/model"
 )
 
-func NewAttribute(ctx context.Context, msg []byte) error {
-	// Пример десериализации JSON сообщения
-	var data map[string]interface{}
-	if err := json.Unmarshal(msg, &data); err != nil {
-		return fmt.Errorf("failed to parse JSON: %w", err)
+type Handler struct {
+	dbR         DBRepo
+	maxAttempts int
+	baseBackoff time.Duration
+	maxBackoff  time.Duration
+}
+
+func New(dbR DBRepo, cfg config.Consumer) *Handler {
+	return &Handler{
+		dbR:         dbR,
+		maxAttempts: max(cfg.MaxAttempts, 1),
+		baseBackoff: cfg.BaseBackoff,
+		maxBackoff:  cfg.MaxBackoff,
+	}
+}
+
+// Handler сохраняет значение атрибута в локальный справочник; сообщение, которое не удалось
+// обработать за все попытки, уходит в DLQ, чтобы не блокировать и не терять поток.
+// Если недоступна и DLQ, ошибка возвращается, и consumer повторяет то же сообщение
+func (h *Handler) Handler(ctx context.Context, msg []byte) error {
+	attempts, err := h.process(ctx, msg)
+	if err == nil {
+		return nil
+	}
+
+	log.Printf("failed to handle attribute message after %d attempts, moving to DLQ: %v", attempts, err)
+	if dlqErr := h.dbR.SaveDeadLetter(ctx, msg, err.Error(), attempts); dlqErr != nil {
+		return fmt.Errorf("failed to move message to DLQ: %w (handle error: %w)", dlqErr, err)
+	}
+
+	return nil
+}
+
+// Replay повторно прогоняет сообщения из DLQ через обработчик; неудачные остаются в DLQ с новой ошибкой
+func (h *Handler) Replay(ctx context.Context, batchSize uint64) (int, int, error) {
+	var afterID int64
+	replayed, failed := 0, 0
+	for {
+		letters, err := h.dbR.GetDeadLetters(ctx, afterID, batchSize)
+		if err != nil {
+			return replayed, failed, err
+		}
+
+		for _, letter := range letters {
+			afterID = letter.ID
+
+			_, handleErr := h.process(ctx, letter.Payload)
+			if err := h.dbR.ResolveDeadLetter(ctx, letter.ID, handleErr); err != nil {
+				return replayed, failed, err
+			}
+			if handleErr != nil {
+				failed++
+			} else {
+				replayed++
+			}
+		}
+
+		if uint64(len(letters)) < batchSize || ctx.Err() != nil {
+			return replayed, failed, ctx.Err()
+		}
+	}
+}
+
+// process повторяет обработку с экспоненциальной задержкой; невалидное сообщение не повторяется
+func (h *Handler) process(ctx context.Context, msg []byte) (int, error) {
+	var err error
+	for attempt := 1; ; attempt++ {
+		err = h.handle(ctx, msg)
+		if err == nil || errors.Is(err, model.ErrInvalidAttributeValue) || attempt >= h.maxAttempts {
+			return attempt, err
+		}
+
+		select {
+		case <-ctx.Done():
+			return attempt, errors.Join(err, ctx.Err())
+		case <-time.After(h.backoff(attempt)):
+		}
+	}
+}
+
+func (h *Handler) handle(ctx context.Context, msg []byte) error {
+	var value model.AttributeValue
+	if err := json.Unmarshal(msg, &value); err != nil {
+		return fmt.Errorf("%w: failed to parse JSON: %v", model.ErrInvalidAttributeValue, err)
+	}
+
+	if err := value.Validate(); err != nil {
+		return err
+	}
+
+	if err := h.dbR.UpsertAttributeValue(ctx, value); err != nil {
+		return fmt.Errorf("failed to save attribute value: %w", err)
 	}
 
-	// Обработка сообщения
-	fmt.Printf("Received message: %v\n", data)
 	return nil
 }
+
+func (h *Handler) backoff(attempt int) time.Duration {
+	delay := h.baseBackoff
+	for i := 1; i < attempt && delay < h.maxBackoff; i++ {
+		delay *= 2
+	}
+	return min(delay, h.maxBackoff)
+}
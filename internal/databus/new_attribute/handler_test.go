@@ -0,0 +1,143 @@
+package new_attribute
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/s21platform/advert-service/internal/config"
+	"github.com/s21platform/advert-service/internal/model"
+)
+
+var testConsumer = config.Consumer{MaxAttempts: 3, BaseBackoff: time.Millisecond, MaxBackoff: 2 * time.Millisecond}
+
+func TestHandler_Handler(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	msg := []byte(`{"attribute":"os","value_id":3,"name":"Linux","updated_at":"2026-01-02T03:04:05Z"}`)
+
+	t.Run("upsert_ok", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockRepo := NewMockDBRepo(ctrl)
+
+		mockRepo.EXPECT().UpsertAttributeValue(ctx, model.AttributeValue{
+			Attribute: model.AttributeOs,
+			ValueID:   3,
+			Name:      "Linux",
+			UpdatedAt: time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC),
+		}).Return(nil)
+
+		err := New(mockRepo, testConsumer).Handler(ctx, msg)
+		assert.NoError(t, err)
+	})
+
+	t.Run("retry_ok", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockRepo := NewMockDBRepo(ctrl)
+
+		gomock.InOrder(
+			mockRepo.EXPECT().UpsertAttributeValue(ctx, gomock.Any()).Return(errors.New("db is down")),
+			mockRepo.EXPECT().UpsertAttributeValue(ctx, gomock.Any()).Return(nil),
+		)
+
+		err := New(mockRepo, testConsumer).Handler(ctx, msg)
+		assert.NoError(t, err)
+	})
+
+	t.Run("invalid_json_to_dlq", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockRepo := NewMockDBRepo(ctrl)
+		poison := []byte(`{"attribute":`)
+
+		mockRepo.EXPECT().SaveDeadLetter(ctx, poison, gomock.Any(), 1).DoAndReturn(
+			func(_ context.Context, _ []byte, reason string, _ int) error {
+				assert.Contains(t, reason, "failed to parse JSON")
+				return nil
+			})
+
+		err := New(mockRepo, testConsumer).Handler(ctx, poison)
+		assert.NoError(t, err)
+	})
+
+	t.Run("invalid_value_to_dlq", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockRepo := NewMockDBRepo(ctrl)
+		poison := []byte(`{"attribute":"os","value_id":0,"name":"Linux"}`)
+
+		mockRepo.EXPECT().SaveDeadLetter(ctx, poison, gomock.Any(), 1).Return(nil)
+
+		err := New(mockRepo, testConsumer).Handler(ctx, poison)
+		assert.NoError(t, err)
+	})
+
+	t.Run("attempts_exhausted_to_dlq", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockRepo := NewMockDBRepo(ctrl)
+
+		mockRepo.EXPECT().UpsertAttributeValue(ctx, gomock.Any()).Return(errors.New("db is down")).Times(3)
+		mockRepo.EXPECT().SaveDeadLetter(ctx, msg, gomock.Any(), 3).Return(nil)
+
+		err := New(mockRepo, testConsumer).Handler(ctx, msg)
+		assert.NoError(t, err)
+	})
+
+	t.Run("dlq_err", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockRepo := NewMockDBRepo(ctrl)
+		expectedErr := errors.New("dlq is down")
+
+		mockRepo.EXPECT().SaveDeadLetter(ctx, gomock.Any(), gomock.Any(), 1).Return(expectedErr)
+
+		err := New(mockRepo, testConsumer).Handler(ctx, []byte(`not json`))
+		assert.ErrorIs(t, err, expectedErr)
+		assert.ErrorIs(t, err, model.ErrInvalidAttributeValue)
+	})
+}
+
+func TestHandler_Replay(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	valid := []byte(`{"attribute":"os","value_id":3,"name":"Linux"}`)
+	poison := []byte(`not json`)
+
+	t.Run("replay_batches", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockRepo := NewMockDBRepo(ctrl)
+
+		gomock.InOrder(
+			mockRepo.EXPECT().GetDeadLetters(ctx, int64(0), uint64(2)).Return([]model.DeadLetter{
+				{ID: 1, Payload: valid},
+				{ID: 4, Payload: poison},
+			}, nil),
+			mockRepo.EXPECT().GetDeadLetters(ctx, int64(4), uint64(2)).Return([]model.DeadLetter{
+				{ID: 7, Payload: valid},
+			}, nil),
+		)
+		mockRepo.EXPECT().UpsertAttributeValue(ctx, gomock.Any()).Return(nil).Times(2)
+		mockRepo.EXPECT().ResolveDeadLetter(ctx, int64(1), nil).Return(nil)
+		mockRepo.EXPECT().ResolveDeadLetter(ctx, int64(4), gomock.Not(nil)).Return(nil)
+		mockRepo.EXPECT().ResolveDeadLetter(ctx, int64(7), nil).Return(nil)
+
+		replayed, failed, err := New(mockRepo, testConsumer).Replay(ctx, 2)
+		assert.NoError(t, err)
+		assert.Equal(t, 2, replayed)
+		assert.Equal(t, 1, failed)
+	})
+
+	t.Run("repo_err", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockRepo := NewMockDBRepo(ctrl)
+		expectedErr := errors.New("db is down")
+
+		mockRepo.EXPECT().GetDeadLetters(ctx, int64(0), uint64(2)).Return(nil, expectedErr)
+
+		_, _, err := New(mockRepo, testConsumer).Replay(ctx, 2)
+		assert.ErrorIs(t, err, expectedErr)
+	})
+}
@@ -0,0 +1,93 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: contract.go
+
+// Package new_attribute is a generated GoMock package.
+package new_attribute
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	model "github.com/s21platform/advert-service/internal/model"
+)
+
+// MockDBRepo is a mock of DBRepo interface.
+type MockDBRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockDBRepoMockRecorder
+}
+
+// MockDBRepoMockRecorder is the mock recorder for MockDBRepo.
+type MockDBRepoMockRecorder struct {
+	mock *MockDBRepo
+}
+
+// NewMockDBRepo creates a new mock instance.
+func NewMockDBRepo(ctrl *gomock.Controller) *MockDBRepo {
+	mock := &MockDBRepo{ctrl: ctrl}
+	mock.recorder = &MockDBRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDBRepo) EXPECT() *MockDBRepoMockRecorder {
+	return m.recorder
+}
+
+// GetDeadLetters mocks base method.
+func (m *MockDBRepo) GetDeadLetters(ctx context.Context, afterID int64, limit uint64) ([]model.DeadLetter, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDeadLetters", ctx, afterID, limit)
+	ret0, _ := ret[0].([]model.DeadLetter)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDeadLetters indicates an expected call of GetDeadLetters.
+func (mr *MockDBRepoMockRecorder) GetDeadLetters(ctx, afterID, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeadLetters", reflect.TypeOf((*MockDBRepo)(nil).GetDeadLetters), ctx, afterID, limit)
+}
+
+// ResolveDeadLetter mocks base method.
+func (m *MockDBRepo) ResolveDeadLetter(ctx context.Context, ID int64, replayErr error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResolveDeadLetter", ctx, ID, replayErr)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResolveDeadLetter indicates an expected call of ResolveDeadLetter.
+func (mr *MockDBRepoMockRecorder) ResolveDeadLetter(ctx, ID, replayErr interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResolveDeadLetter", reflect.TypeOf((*MockDBRepo)(nil).ResolveDeadLetter), ctx, ID, replayErr)
+}
+
+// SaveDeadLetter mocks base method.
+func (m *MockDBRepo) SaveDeadLetter(ctx context.Context, payload []byte, reason string, attempts int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveDeadLetter", ctx, payload, reason, attempts)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveDeadLetter indicates an expected call of SaveDeadLetter.
+func (mr *MockDBRepoMockRecorder) SaveDeadLetter(ctx, payload, reason, attempts interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveDeadLetter", reflect.TypeOf((*MockDBRepo)(nil).SaveDeadLetter), ctx, payload, reason, attempts)
+}
+
+// UpsertAttributeValue mocks base method.
+func (m *MockDBRepo) UpsertAttributeValue(ctx context.Context, value model.AttributeValue) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertAttributeValue", ctx, value)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpsertAttributeValue indicates an expected call of UpsertAttributeValue.
+func (mr *MockDBRepoMockRecorder) UpsertAttributeValue(ctx, value interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertAttributeValue", reflect.TypeOf((*MockDBRepo)(nil).UpsertAttributeValue), ctx, value)
+}
@@ -0,0 +1,158 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"google.golang.org/grpc/health"
+	"google.golang.org/grpc/health/grpc_health_v1"
+
+	"github.com/s21platform/advert-service/internal/infra"
+)
+
+// Check проверяет одну зависимость сервиса
+type Check func(ctx context.Context) error
+
+// Checker собирает проверки зависимостей и отдаёт их результат по gRPC health и HTTP
+type Checker struct {
+	mu       sync.RWMutex
+	names    []string
+	checks   map[string]Check
+	timeout  time.Duration
+	stopping atomic.Bool
+}
+
+func New(timeout time.Duration) *Checker {
+	return &Checker{checks: make(map[string]Check), timeout: timeout}
+}
+
+func (c *Checker) Add(name string, check Check) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, ok := c.checks[name]; !ok {
+		c.names = append(c.names, name)
+	}
+	c.checks[name] = check
+}
+
+// Check запускает все проверки параллельно; в результате ошибка по каждой упавшей зависимости
+func (c *Checker) Check(ctx context.Context) map[string]error {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	var (
+		mu     sync.Mutex
+		wg     sync.WaitGroup
+		failed = make(map[string]error)
+	)
+	for _, name := range c.names {
+		wg.Add(1)
+		go func(name string, check Check) {
+			defer wg.Done()
+
+			checkCtx, cancel := context.WithTimeout(ctx, c.timeout)
+			defer cancel()
+
+			if err := check(checkCtx); err != nil {
+				mu.Lock()
+				failed[name] = err
+				mu.Unlock()
+			}
+		}(name, c.checks[name])
+	}
+	wg.Wait()
+
+	return failed
+}
+
+// Shutdown переводит сервис в not ready, чтобы балансировщик перестал слать трафик до остановки
+func (c *Checker) Shutdown() {
+	c.stopping.Store(true)
+}
+
+func (c *Checker) ready(ctx context.Context) (bool, map[string]error) {
+	if c.stopping.Load() {
+		return false, nil
+	}
+	failed := c.Check(ctx)
+	return len(failed) == 0, failed
+}
+
+// Watch раз в интервал обновляет статус gRPC health для всего сервера и перечисленных сервисов.
+// До первой успешной проверки статус NOT_SERVING
+func (c *Checker) Watch(ctx context.Context, srv *health.Server, interval time.Duration, services ...string) {
+	services = append([]string{""}, services...)
+	set := func(status grpc_health_v1.HealthCheckResponse_ServingStatus) {
+		for _, service := range services {
+			srv.SetServingStatus(service, status)
+		}
+	}
+	set(grpc_health_v1.HealthCheckResponse_NOT_SERVING)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if ok, _ := c.ready(ctx); ok {
+			set(grpc_health_v1.HealthCheckResponse_SERVING)
+		} else {
+			set(grpc_health_v1.HealthCheckResponse_NOT_SERVING)
+		}
+
+		select {
+		case <-ctx.Done():
+			srv.Shutdown()
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Handler отдаёт /livez (процесс жив) и /readyz (зависимости доступны)
+func (c *Checker) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/livez", func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte("ok"))
+	})
+	mux.HandleFunc("/readyz", func(w http.ResponseWriter, r *http.Request) {
+		ok, failed := c.ready(r.Context())
+
+		body := make(map[string]string, len(failed))
+		for name, err := range failed {
+			body[name] = err.Error()
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if ok {
+			w.WriteHeader(http.StatusOK)
+		} else {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"ready": ok, "failed": body})
+	})
+	return mux
+}
+
+// Serve поднимает HTTP-сервер проверок и останавливает его вместе с ctx
+func (c *Checker) Serve(ctx context.Context, port string) error {
+	return infra.ServeHTTP(ctx, port, c.Handler(), c.timeout)
+}
+
+// TCPCheck проверяет, что адрес принимает соединения; годится для брокера kafka
+func TCPCheck(addr string) Check {
+	return func(ctx context.Context) error {
+		conn, err := (&net.Dialer{}).DialContext(ctx, "tcp", addr)
+		if err != nil {
+			return fmt.Errorf("failed to reach %s: %w", addr, err)
+		}
+		return conn.Close()
+	}
+}
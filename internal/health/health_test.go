@@ -0,0 +1,87 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/health"
+	"google.golang.org/grpc/health/grpc_health_v1"
+)
+
+func TestChecker_Check(t *testing.T) {
+	t.Parallel()
+
+	expectedErr := errors.New("connection refused")
+
+	checker := New(time.Second)
+	checker.Add("postgres", func(context.Context) error { return nil })
+	checker.Add("kafka", func(context.Context) error { return expectedErr })
+
+	failed := checker.Check(context.Background())
+	assert.Equal(t, map[string]error{"kafka": expectedErr}, failed)
+}
+
+func TestChecker_Handler(t *testing.T) {
+	t.Parallel()
+
+	healthy := true
+	checker := New(time.Second)
+	checker.Add("postgres", func(context.Context) error {
+		if healthy {
+			return nil
+		}
+		return errors.New("connection refused")
+	})
+	handler := checker.Handler()
+
+	get := func(path string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		return rec
+	}
+
+	assert.Equal(t, http.StatusOK, get("/livez").Code)
+	assert.Equal(t, http.StatusOK, get("/readyz").Code)
+
+	healthy = false
+	rec := get("/readyz")
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+	assert.Contains(t, rec.Body.String(), "connection refused")
+	assert.Equal(t, http.StatusOK, get("/livez").Code)
+
+	healthy = true
+	checker.Shutdown()
+	assert.Equal(t, http.StatusServiceUnavailable, get("/readyz").Code)
+}
+
+func TestChecker_Watch(t *testing.T) {
+	t.Parallel()
+
+	checker := New(time.Second)
+	checker.Add("postgres", func(context.Context) error { return nil })
+	srv := health.NewServer()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		checker.Watch(ctx, srv, time.Millisecond, "advert.AdvertService")
+		close(done)
+	}()
+
+	assert.Eventually(t, func() bool {
+		resp, err := srv.Check(context.Background(), &grpc_health_v1.HealthCheckRequest{Service: "advert.AdvertService"})
+		return err == nil && resp.Status == grpc_health_v1.HealthCheckResponse_SERVING
+	}, time.Second, time.Millisecond)
+
+	cancel()
+	<-done
+
+	resp, err := srv.Check(context.Background(), &grpc_health_v1.HealthCheckRequest{})
+	assert.NoError(t, err)
+	assert.Equal(t, grpc_health_v1.HealthCheckResponse_NOT_SERVING, resp.Status)
+}
@@ -2,32 +2,38 @@ package infra
 
 import (
 	"context"
+	"errors"
+	"strings"
 
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/grpc/status"
 
+	"github.com/s21platform/advert-service/internal/auth"
 	"github.com/s21platform/advert-service/internal/config"
+	"github.com/s21platform/advert-service/internal/model"
 )
 
-func AuthInterceptor(
-	ctx context.Context,
-	req interface{},
-	_ *grpc.UnaryServerInfo,
-	handler grpc.UnaryHandler,
-) (interface{}, error) {
-	md, ok := metadata.FromIncomingContext(ctx)
-	if !ok {
-		return nil, status.Errorf(codes.Unauthenticated, "no info in metadata")
-	}
+// AuthInterceptor определяет вызывающего через authenticator и кладёт его uuid и роль в контекст
+func AuthInterceptor(authenticator auth.Authenticator) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		// пробы оркестратора ходят без пользовательских метаданных
+		if strings.HasPrefix(info.FullMethod, "/"+grpc_health_v1.Health_ServiceDesc.ServiceName+"/") {
+			return handler(ctx, req)
+		}
 
-	userIDs, ok := md["uuid"]
-	if !ok || len(userIDs) != 1 {
-		return nil, status.Errorf(codes.Unauthenticated, "no uuid or more than one in metadata")
-	}
+		caller, err := authenticator.Authenticate(ctx)
+		if err != nil {
+			if errors.Is(err, model.ErrPermissionDenied) {
+				return nil, status.Error(codes.PermissionDenied, err.Error())
+			}
+			return nil, status.Error(codes.Unauthenticated, err.Error())
+		}
 
-	ctx = context.WithValue(ctx, config.KeyUUID, userIDs[0])
+		ctx = context.WithValue(ctx, config.KeyUUID, caller.UUID)
+		ctx = context.WithValue(ctx, config.KeyRole, caller.Role)
 
-	return handler(ctx, req)
+		return handler(ctx, req)
+	}
 }
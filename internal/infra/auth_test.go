@@ -0,0 +1,101 @@
+package infra
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/s21platform/advert-service/internal/auth"
+	"github.com/s21platform/advert-service/internal/config"
+	"github.com/s21platform/advert-service/internal/model"
+)
+
+const (
+	testSecret   = "test-secret"
+	testUserUUID = "6f2b0c1e-8d4a-4b7e-9c3f-1a2b3c4d5e6f"
+)
+
+func bearer(t *testing.T, key []byte, claims jwt.MapClaims) context.Context {
+	t.Helper()
+
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(key)
+	assert.NoError(t, err)
+	return metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer "+token))
+}
+
+func TestAuthInterceptor(t *testing.T) {
+	t.Parallel()
+
+	chain, err := auth.New(config.Auth{JWTSecret: testSecret, AllowRawUUID: true, UUIDClaim: "uuid", RolesClaim: "roles"})
+	assert.NoError(t, err)
+	interceptor := AuthInterceptor(chain)
+
+	info := &grpc.UnaryServerInfo{FullMethod: "/advert.AdvertService/GetAdverts"}
+	claims := jwt.MapClaims{"uuid": testUserUUID, "roles": []string{"moderator"}, "exp": time.Now().Add(time.Hour).Unix()}
+
+	t.Run("caller_in_context", func(t *testing.T) {
+		var gotUUID, gotRole interface{}
+		_, err := interceptor(bearer(t, []byte(testSecret), claims), nil, info, func(ctx context.Context, _ interface{}) (interface{}, error) {
+			gotUUID = ctx.Value(config.KeyUUID)
+			gotRole = ctx.Value(config.KeyRole)
+			return nil, nil
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, testUserUUID, gotUUID)
+		assert.Equal(t, model.RoleModerator, gotRole)
+	})
+
+	t.Run("health_skips_auth", func(t *testing.T) {
+		called := false
+		healthInfo := &grpc.UnaryServerInfo{FullMethod: "/grpc.health.v1.Health/Check"}
+
+		_, err := interceptor(context.Background(), nil, healthInfo, func(context.Context, interface{}) (interface{}, error) {
+			called = true
+			return nil, nil
+		})
+
+		assert.NoError(t, err)
+		assert.True(t, called)
+	})
+
+	tests := []struct {
+		name string
+		ctx  context.Context
+		want codes.Code
+	}{
+		{
+			name: "no_credentials",
+			ctx:  context.Background(),
+			want: codes.Unauthenticated,
+		},
+		{
+			name: "invalid_token",
+			ctx:  bearer(t, []byte("other-secret"), claims),
+			want: codes.Unauthenticated,
+		},
+		{
+			name: "unknown_raw_role",
+			ctx:  metadata.NewIncomingContext(context.Background(), metadata.Pairs("uuid", testUserUUID, "role", "admin")),
+			want: codes.PermissionDenied,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := interceptor(tt.ctx, nil, info, func(context.Context, interface{}) (interface{}, error) {
+				t.Fatal("handler must not be called")
+				return nil, nil
+			})
+
+			assert.Equal(t, tt.want, status.Code(err))
+		})
+	}
+}
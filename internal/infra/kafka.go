@@ -0,0 +1,356 @@
+package infra
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+
+	kafka_lib "github.com/s21platform/kafka-lib"
+)
+
+// kafka-lib не даёт доступа к заголовкам сообщений, поэтому producer и consumer ниже повторяют
+// его поведение поверх kafka-go и переносят trace context через заголовки
+
+var kafkaTracer = otel.Tracer("github.com/s21platform/advert-service/internal/infra/kafka")
+
+// headerCarrier адаптирует заголовки kafka к propagation.TextMapCarrier
+type headerCarrier struct {
+	headers *[]kafka.Header
+}
+
+func (c headerCarrier) Get(key string) string {
+	for _, h := range *c.headers {
+		if h.Key == key {
+			return string(h.Value)
+		}
+	}
+	return ""
+}
+
+func (c headerCarrier) Set(key, value string) {
+	for i, h := range *c.headers {
+		if h.Key == key {
+			(*c.headers)[i].Value = []byte(value)
+			return
+		}
+	}
+	*c.headers = append(*c.headers, kafka.Header{Key: key, Value: []byte(value)})
+}
+
+func (c headerCarrier) Keys() []string {
+	keys := make([]string, 0, len(*c.headers))
+	for _, h := range *c.headers {
+		keys = append(keys, h.Key)
+	}
+	return keys
+}
+
+// batchTimeout заменяет секунду kafka-go по умолчанию: синхронная запись неполной пачки ждёт его целиком
+const batchTimeout = 10 * time.Millisecond
+
+// messageWriter - часть *kafka.Writer, нужная producer'у
+type messageWriter interface {
+	WriteMessages(ctx context.Context, msgs ...kafka.Message) error
+	Close() error
+}
+
+type TracedProducer struct {
+	writer messageWriter
+	topic  string
+}
+
+func NewTracedProducer(cfg kafka_lib.ProducerConfig) *TracedProducer {
+	return &TracedProducer{
+		writer: &kafka.Writer{
+			Addr:  kafka.TCP(cfg.Host + ":" + cfg.Port),
+			Topic: cfg.Topic,
+			// Hash кладёт сообщения с одинаковым ключом в одну партицию, сообщения без ключа - по кругу
+			Balancer:     &kafka.Hash{},
+			RequiredAcks: cfg.RequiredAcks,
+			BatchSize:    cfg.BatchSize,
+			BatchTimeout: batchTimeout,
+			WriteTimeout: cfg.Timeout,
+		},
+		topic: cfg.Topic,
+	}
+}
+
+// ProduceMessage совместим с kafka-lib: сообщение сериализуется в JSON, ключ - в байты
+func (p *TracedProducer) ProduceMessage(ctx context.Context, message any, key any) error {
+	ctx, span := kafkaTracer.Start(ctx, p.topic+" publish",
+		trace.WithSpanKind(trace.SpanKindProducer),
+		trace.WithAttributes(
+			semconv.MessagingSystemKafka,
+			semconv.MessagingDestinationName(p.topic),
+			semconv.MessagingOperationTypePublish,
+		),
+	)
+	defer span.End()
+
+	value, err := json.Marshal(message)
+	if err != nil {
+		return spanError(span, fmt.Errorf("failed to marshal message: %w", err))
+	}
+
+	keyBytes, err := messageKey(key)
+	if err != nil {
+		return spanError(span, fmt.Errorf("failed to convert key to bytes: %w", err))
+	}
+
+	var headers []kafka.Header
+	otel.GetTextMapPropagator().Inject(ctx, headerCarrier{headers: &headers})
+
+	err = p.writer.WriteMessages(ctx, kafka.Message{Key: keyBytes, Value: value, Headers: headers})
+	if err != nil {
+		return spanError(span, fmt.Errorf("failed to write message: %w", err))
+	}
+	return nil
+}
+
+// Message - сообщение для ProduceMessages. Ctx - контекст конкретного сообщения, из него берётся trace
+type Message struct {
+	Ctx   context.Context
+	Value any
+	Key   any
+}
+
+// ProduceMessages отправляет сообщения одной записью и возвращает ошибку для каждого сообщения, nil - отправлено
+func (p *TracedProducer) ProduceMessages(ctx context.Context, messages []Message) []error {
+	errs := make([]error, len(messages))
+	spans := make([]trace.Span, len(messages))
+	batch := make([]kafka.Message, 0, len(messages))
+	// index[i] - позиция сообщения batch[i] во входном срезе
+	index := make([]int, 0, len(messages))
+
+	for i, m := range messages {
+		msgCtx, span := kafkaTracer.Start(m.Ctx, p.topic+" publish",
+			trace.WithSpanKind(trace.SpanKindProducer),
+			trace.WithAttributes(
+				semconv.MessagingSystemKafka,
+				semconv.MessagingDestinationName(p.topic),
+				semconv.MessagingOperationTypePublish,
+				semconv.MessagingBatchMessageCount(len(messages)),
+			),
+		)
+		spans[i] = span
+
+		value, err := json.Marshal(m.Value)
+		if err != nil {
+			errs[i] = fmt.Errorf("failed to marshal message: %w", err)
+			continue
+		}
+		key, err := messageKey(m.Key)
+		if err != nil {
+			errs[i] = fmt.Errorf("failed to convert key to bytes: %w", err)
+			continue
+		}
+
+		var headers []kafka.Header
+		otel.GetTextMapPropagator().Inject(msgCtx, headerCarrier{headers: &headers})
+		batch = append(batch, kafka.Message{Key: key, Value: value, Headers: headers})
+		index = append(index, i)
+	}
+
+	if len(batch) > 0 {
+		err := p.writer.WriteMessages(ctx, batch...)
+		var writeErrs kafka.WriteErrors
+		switch {
+		case errors.As(err, &writeErrs):
+			for j, writeErr := range writeErrs {
+				if writeErr != nil {
+					errs[index[j]] = fmt.Errorf("failed to write message: %w", writeErr)
+				}
+			}
+		case err != nil:
+			for _, i := range index {
+				errs[i] = fmt.Errorf("failed to write messages: %w", err)
+			}
+		}
+	}
+
+	for i, span := range spans {
+		if errs[i] != nil {
+			_ = spanError(span, errs[i])
+		}
+		span.End()
+	}
+	return errs
+}
+
+func (p *TracedProducer) Close() error {
+	return p.writer.Close()
+}
+
+// commitTimeout ограничивает коммит, который после отмены ctx идёт уже без него
+const commitTimeout = 10 * time.Second
+
+// retryBackoff и maxRetryBackoff задают паузы между повторами сообщения, которое не удалось обработать
+const (
+	retryBackoff    = time.Second
+	maxRetryBackoff = 30 * time.Second
+)
+
+// messageReader - часть *kafka.Reader, нужная consumer'у
+type messageReader interface {
+	FetchMessage(ctx context.Context) (kafka.Message, error)
+	CommitMessages(ctx context.Context, msgs ...kafka.Message) error
+	Config() kafka.ReaderConfig
+	Close() error
+}
+
+type TracedConsumer struct {
+	reader  messageReader
+	metrics kafka_lib.Metrics
+	topic   string
+	// stopped закрывается, когда цикл чтения вышел после отмены ctx
+	stopped chan struct{}
+
+	retryBackoff    time.Duration
+	maxRetryBackoff time.Duration
+}
+
+func NewTracedConsumer(cfg kafka_lib.ConsumerConfig, metrics kafka_lib.Metrics) (*TracedConsumer, error) {
+	if metrics == nil {
+		return nil, kafka_lib.ErrNoMetrics
+	}
+	return &TracedConsumer{
+		reader: kafka.NewReader(kafka.ReaderConfig{
+			Brokers: []string{cfg.Host + ":" + cfg.Port},
+			Topic:   cfg.Topic,
+			GroupID: cfg.GroupID,
+		}),
+		metrics:         metrics,
+		topic:           cfg.Topic,
+		retryBackoff:    retryBackoff,
+		maxRetryBackoff: maxRetryBackoff,
+	}, nil
+}
+
+// RegisterHandler читает сообщения до отмены ctx; коммитит только успешно обработанные.
+// Сообщение с ошибкой повторяется, а не пропускается: коммит следующего сдвинул бы offset за него.
+// Handler получает контекст с trace context из заголовков сообщения
+func (c *TracedConsumer) RegisterHandler(ctx context.Context, handler func(context.Context, []byte) error) {
+	metric := fmt.Sprintf("consume.%s.%s", c.reader.Config().GroupID, strings.ReplaceAll(c.topic, ".", "_"))
+
+	c.stopped = make(chan struct{})
+	go func() {
+		defer close(c.stopped)
+
+		for {
+			msg, err := c.reader.FetchMessage(ctx)
+			if err != nil {
+				if ctx.Err() != nil {
+					return
+				}
+				log.Printf("failed to read message: %v", err)
+				c.metrics.Increment(metric + ".error")
+				continue
+			}
+
+			start := time.Now()
+			if !c.process(ctx, msg, handler, metric) {
+				// без коммита сообщение придёт снова после рестарта
+				return
+			}
+			if err = c.commit(ctx, msg); err != nil {
+				log.Printf("failed to commit message: %v", err)
+				c.metrics.Increment(metric + ".error")
+				continue
+			}
+			c.metrics.Increment(metric + ".ok")
+			c.metrics.Duration(time.Since(start).Milliseconds(), metric)
+		}
+	}()
+}
+
+// process повторяет обработку сообщения с растущей паузой, пока она не удастся; false - ctx отменён раньше
+func (c *TracedConsumer) process(ctx context.Context, msg kafka.Message, handler func(context.Context, []byte) error, metric string) bool {
+	delay := c.retryBackoff
+	for {
+		err := c.handle(ctx, msg, handler)
+		if err == nil {
+			return true
+		}
+		log.Printf("failed to handle message at offset %d, retrying in %s: %v", msg.Offset, delay, err)
+		c.metrics.Increment(metric + ".error")
+
+		select {
+		case <-ctx.Done():
+			return false
+		case <-time.After(delay):
+		}
+		delay = min(delay*2, c.maxRetryBackoff)
+	}
+}
+
+// commit не зависит от отмены ctx: сообщение, дообработанное при остановке, иначе придёт повторно после рестарта
+func (c *TracedConsumer) commit(ctx context.Context, msg kafka.Message) error {
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), commitTimeout)
+	defer cancel()
+
+	return c.reader.CommitMessages(ctx, msg)
+}
+
+func (c *TracedConsumer) handle(ctx context.Context, msg kafka.Message, handler func(context.Context, []byte) error) error {
+	ctx = otel.GetTextMapPropagator().Extract(ctx, headerCarrier{headers: &msg.Headers})
+	ctx, span := kafkaTracer.Start(ctx, c.topic+" process",
+		trace.WithSpanKind(trace.SpanKindConsumer),
+		trace.WithAttributes(
+			semconv.MessagingSystemKafka,
+			semconv.MessagingDestinationName(c.topic),
+			semconv.MessagingOperationTypeDeliver,
+			attribute.Int64("messaging.kafka.offset", msg.Offset),
+			attribute.Int("messaging.kafka.partition", msg.Partition),
+		),
+	)
+	defer span.End()
+
+	if err := handler(ctx, msg.Value); err != nil {
+		return spanError(span, err)
+	}
+	return nil
+}
+
+// Close дожидается коммита последнего обработанного сообщения; ctx из RegisterHandler должен быть уже отменён
+func (c *TracedConsumer) Close() error {
+	if c.stopped != nil {
+		select {
+		case <-c.stopped:
+		case <-time.After(commitTimeout):
+			log.Println("kafka consumer did not stop, closing reader")
+		}
+	}
+	return c.reader.Close()
+}
+
+func messageKey(key any) ([]byte, error) {
+	switch v := key.(type) {
+	case []byte:
+		return v, nil
+	case string:
+		return []byte(v), nil
+	case int, int32, int64, uint, uint32, uint64:
+		return []byte(fmt.Sprintf("%v", v)), nil
+	case nil:
+		return nil, nil
+	default:
+		return json.Marshal(v)
+	}
+}
+
+func spanError(span trace.Span, err error) error {
+	span.RecordError(err)
+	span.SetStatus(codes.Error, err.Error())
+	return err
+}
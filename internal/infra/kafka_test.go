@@ -0,0 +1,292 @@
+package infra
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/s21platform/advert-service/internal/config"
+	"github.com/s21platform/advert-service/internal/databus/new_attribute"
+	"github.com/s21platform/advert-service/internal/model"
+)
+
+// fakeReader отдаёт сообщения из msgs и, как kafka-go, не коммитит с отменённым контекстом
+type fakeReader struct {
+	msgs      chan kafka.Message
+	committed chan kafka.Message
+}
+
+func (r *fakeReader) FetchMessage(ctx context.Context) (kafka.Message, error) {
+	select {
+	case msg := <-r.msgs:
+		return msg, nil
+	case <-ctx.Done():
+		return kafka.Message{}, ctx.Err()
+	}
+}
+
+func (r *fakeReader) CommitMessages(ctx context.Context, msgs ...kafka.Message) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	for _, msg := range msgs {
+		r.committed <- msg
+	}
+	return nil
+}
+
+func (r *fakeReader) Config() kafka.ReaderConfig {
+	return kafka.ReaderConfig{GroupID: "test_group"}
+}
+
+func (r *fakeReader) Close() error {
+	return nil
+}
+
+func TestTracedConsumer_CommitsAfterCancel(t *testing.T) {
+	t.Parallel()
+
+	reader := &fakeReader{msgs: make(chan kafka.Message, 1), committed: make(chan kafka.Message, 1)}
+	consumer := &TracedConsumer{reader: reader, metrics: NewRecorder(nil, false), topic: "attribute"}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	drainer := &Drainer{}
+	consumer.RegisterHandler(ctx, drainer.Wrap(func(context.Context, []byte) error {
+		// сигнал остановки приходит посреди обработки
+		cancel()
+		return nil
+	}))
+
+	reader.msgs <- kafka.Message{Offset: 42, Value: []byte(`{}`)}
+
+	select {
+	case msg := <-reader.committed:
+		assert.Equal(t, int64(42), msg.Offset)
+	case <-time.After(time.Second):
+		t.Fatal("message handled during shutdown was not committed")
+	}
+	assert.True(t, drainer.Drain(time.Second))
+	assert.NoError(t, consumer.Close())
+}
+
+// unavailableRepo эмулирует недоступный postgres: пока down, не пишутся ни значения, ни DLQ
+type unavailableRepo struct {
+	mu       sync.Mutex
+	down     bool
+	upserted []int64
+}
+
+func (r *unavailableRepo) setDown(down bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.down = down
+}
+
+func (r *unavailableRepo) UpsertAttributeValue(_ context.Context, value model.AttributeValue) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.down {
+		return errors.New("connection refused")
+	}
+	r.upserted = append(r.upserted, value.ValueID)
+	return nil
+}
+
+func (r *unavailableRepo) SaveDeadLetter(context.Context, []byte, string, int) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.down {
+		return errors.New("connection refused")
+	}
+	return nil
+}
+
+func (r *unavailableRepo) GetDeadLetters(context.Context, int64, uint64) ([]model.DeadLetter, error) {
+	return nil, nil
+}
+
+func (r *unavailableRepo) ResolveDeadLetter(context.Context, int64, error) error {
+	return nil
+}
+
+func TestTracedConsumer_DoesNotSkipFailedMessage(t *testing.T) {
+	t.Parallel()
+
+	failed := kafka.Message{Offset: 1, Value: []byte(`{"attribute":"os","value_id":1,"name":"Linux"}`)}
+	next := kafka.Message{Offset: 2, Value: []byte(`{"attribute":"os","value_id":2,"name":"macOS"}`)}
+
+	start := func(repo *unavailableRepo) (*fakeReader, *TracedConsumer, context.CancelFunc) {
+		reader := &fakeReader{msgs: make(chan kafka.Message, 2), committed: make(chan kafka.Message, 2)}
+		reader.msgs <- failed
+		reader.msgs <- next
+
+		consumer := &TracedConsumer{
+			reader:          reader,
+			metrics:         NewRecorder(nil, false),
+			topic:           "attribute",
+			retryBackoff:    time.Millisecond,
+			maxRetryBackoff: 5 * time.Millisecond,
+		}
+		handler := new_attribute.New(repo, config.Consumer{MaxAttempts: 2, BaseBackoff: time.Millisecond, MaxBackoff: time.Millisecond})
+
+		ctx, cancel := context.WithCancel(context.Background())
+		consumer.RegisterHandler(ctx, handler.Handler)
+		return reader, consumer, cancel
+	}
+
+	t.Run("outage", func(t *testing.T) {
+		repo := &unavailableRepo{down: true}
+		reader, consumer, cancel := start(repo)
+
+		time.Sleep(50 * time.Millisecond)
+		cancel()
+		assert.NoError(t, consumer.Close())
+
+		// следующее сообщение не прочитано, и offset не ушёл за необработанное
+		assert.Len(t, reader.committed, 0)
+		assert.Len(t, reader.msgs, 1)
+	})
+
+	t.Run("recovers_in_order", func(t *testing.T) {
+		repo := &unavailableRepo{down: true}
+		reader, consumer, cancel := start(repo)
+		defer cancel()
+
+		time.Sleep(20 * time.Millisecond)
+		repo.setDown(false)
+
+		for _, want := range []int64{failed.Offset, next.Offset} {
+			select {
+			case msg := <-reader.committed:
+				assert.Equal(t, want, msg.Offset)
+			case <-time.After(time.Second):
+				t.Fatalf("offset %d was not committed", want)
+			}
+		}
+		cancel()
+		assert.NoError(t, consumer.Close())
+		assert.Equal(t, []int64{1, 2}, repo.upserted)
+	})
+}
+
+var propagatorOnce sync.Once
+
+// tracedContext возвращает ctx с удалённым span'ом и включает W3C trace context, как InitTracing
+func tracedContext(t *testing.T) (context.Context, trace.TraceID) {
+	t.Helper()
+	propagatorOnce.Do(func() {
+		otel.SetTextMapPropagator(propagation.TraceContext{})
+	})
+
+	traceID, err := trace.TraceIDFromHex("4bf92f3577b34da6a3ce929d0e0e4736")
+	assert.NoError(t, err)
+	spanID, err := trace.SpanIDFromHex("00f067aa0ba902b7")
+	assert.NoError(t, err)
+
+	sc := trace.NewSpanContext(trace.SpanContextConfig{TraceID: traceID, SpanID: spanID, TraceFlags: trace.FlagsSampled, Remote: true})
+	return trace.ContextWithSpanContext(context.Background(), sc), traceID
+}
+
+func TestTracedConsumer_ContinuesTrace(t *testing.T) {
+	t.Parallel()
+
+	spanCtx, traceID := tracedContext(t)
+
+	var headers []kafka.Header
+	otel.GetTextMapPropagator().Inject(spanCtx, headerCarrier{headers: &headers})
+
+	reader := &fakeReader{msgs: make(chan kafka.Message, 1), committed: make(chan kafka.Message, 1)}
+	reader.msgs <- kafka.Message{Offset: 7, Value: []byte(`{}`), Headers: headers}
+	consumer := &TracedConsumer{reader: reader, metrics: NewRecorder(nil, false), topic: "attribute"}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	handled := make(chan trace.SpanContext, 1)
+	consumer.RegisterHandler(ctx, func(ctx context.Context, _ []byte) error {
+		handled <- trace.SpanContextFromContext(ctx)
+		return nil
+	})
+
+	select {
+	case sc := <-handled:
+		assert.Equal(t, traceID, sc.TraceID())
+	case <-time.After(time.Second):
+		t.Fatal("message was not handled")
+	}
+	<-reader.committed
+	cancel()
+	assert.NoError(t, consumer.Close())
+}
+
+// fakeWriter запоминает записанную пачку и возвращает заданную ошибку
+type fakeWriter struct {
+	written []kafka.Message
+	err     error
+}
+
+func (w *fakeWriter) WriteMessages(_ context.Context, msgs ...kafka.Message) error {
+	w.written = append(w.written, msgs...)
+	return w.err
+}
+
+func (w *fakeWriter) Close() error {
+	return nil
+}
+
+func TestTracedProducer_ProduceMessages(t *testing.T) {
+	t.Parallel()
+
+	spanCtx, traceID := tracedContext(t)
+	ctx := context.Background()
+	writeErr := errors.New("message too large")
+
+	// второе сообщение не сериализуется и в пачку не попадает, поэтому индексы пачки и входа расходятся
+	messages := []Message{
+		{Ctx: spanCtx, Value: "a", Key: int64(1)},
+		{Ctx: ctx, Value: make(chan int), Key: int64(2)},
+		{Ctx: ctx, Value: "c", Key: int64(3)},
+		{Ctx: ctx, Value: "d", Key: int64(4)},
+	}
+
+	t.Run("write_errors_by_index", func(t *testing.T) {
+		writer := &fakeWriter{err: kafka.WriteErrors{nil, writeErr, nil}}
+		producer := &TracedProducer{writer: writer, topic: "advert"}
+
+		errs := producer.ProduceMessages(ctx, messages)
+
+		assert.Len(t, errs, 4)
+		assert.NoError(t, errs[0])
+		assert.ErrorContains(t, errs[1], "failed to marshal message")
+		assert.ErrorIs(t, errs[2], writeErr)
+		assert.NoError(t, errs[3])
+
+		assert.Len(t, writer.written, 3)
+		assert.Equal(t, []byte("3"), writer.written[1].Key)
+		// trace запроса уходит в заголовках своего сообщения
+		sc := trace.SpanContextFromContext(otel.GetTextMapPropagator().Extract(ctx, headerCarrier{headers: &writer.written[0].Headers}))
+		assert.Equal(t, traceID, sc.TraceID())
+	})
+
+	t.Run("batch_error", func(t *testing.T) {
+		writer := &fakeWriter{err: errors.New("broker unavailable")}
+		producer := &TracedProducer{writer: writer, topic: "advert"}
+
+		errs := producer.ProduceMessages(ctx, messages)
+
+		assert.ErrorContains(t, errs[0], "broker unavailable")
+		assert.ErrorContains(t, errs[1], "failed to marshal message")
+		assert.ErrorContains(t, errs[2], "broker unavailable")
+		assert.ErrorContains(t, errs[3], "broker unavailable")
+	})
+}
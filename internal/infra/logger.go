@@ -2,17 +2,271 @@ package infra
 
 import (
 	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
 
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/trace"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
 
 	logger_lib "github.com/s21platform/logger-lib"
+	pkg "github.com/s21platform/metrics-lib/pkg"
 
 	"github.com/s21platform/advert-service/internal/config"
 )
 
-func Logger(logger *logger_lib.Logger) func(context.Context, interface{}, *grpc.UnaryServerInfo, grpc.UnaryHandler) (interface{}, error) {
+const (
+	LevelDebug = "debug"
+
+	levelInfo  = "info"
+	levelWarn  = "warn"
+	levelError = "error"
+
+	requestIDHeader = "x-request-id"
+)
+
+// redactedFields - поля с текстом объявлений, которые не попадают в лог выше уровня debug
+var redactedFields = map[protoreflect.Name]struct{}{
+	"title":        {},
+	"text_content": {},
+}
+
+// LogSink отправляет логи в loki из фоновых воркеров: logger-lib пишет синхронным http.Post без таймаута,
+// и медленный loki иначе задерживал бы каждый запрос. При переполненном буфере записи отбрасываются
+type LogSink struct {
+	entries   chan logEntry
+	newLogger func() logger_lib.LoggerInterface
+	metrics   pkg.MetricInterface
+	wg        sync.WaitGroup
+
+	// mu не даёт писать в закрытый канал, если запросы пережили остановку сервера
+	mu     sync.RWMutex
+	closed bool
+}
+
+type logEntry struct {
+	level    string
+	funcName string
+	msg      string
+}
+
+func NewLogSink(cfg *config.Config, metrics pkg.MetricInterface) *LogSink {
+	return newLogSink(cfg.Logger.BufferSize, cfg.Logger.Workers, metrics, func() logger_lib.LoggerInterface {
+		return logger_lib.New(cfg.Logger.Host, cfg.Logger.Port, cfg.Service.Name, cfg.Platform.Env)
+	})
+}
+
+func newLogSink(size, workers int, metrics pkg.MetricInterface, newLogger func() logger_lib.LoggerInterface) *LogSink {
+	s := &LogSink{
+		entries:   make(chan logEntry, size),
+		newLogger: newLogger,
+		metrics:   metrics,
+	}
+	for i := 0; i < workers; i++ {
+		s.wg.Add(1)
+		go s.run()
+	}
+	return s
+}
+
+// run держит свой экземпляр logger-lib, потому что AddFuncName меняет его состояние
+func (s *LogSink) run() {
+	defer s.wg.Done()
+
+	logger := s.newLogger()
+	for e := range s.entries {
+		logger.AddFuncName(e.funcName)
+		switch e.level {
+		case levelError:
+			logger.Error(e.msg)
+		case levelWarn:
+			logger.Warn(e.msg)
+		default:
+			logger.Info(e.msg)
+		}
+	}
+}
+
+func (s *LogSink) send(e logEntry) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if s.closed {
+		s.metrics.Increment("logger.dropped")
+		return
+	}
+	select {
+	case s.entries <- e:
+	default:
+		s.metrics.Increment("logger.dropped")
+	}
+}
+
+// Close дожидается отправки накопленных записей не дольше timeout
+func (s *LogSink) Close(timeout time.Duration) bool {
+	s.mu.Lock()
+	if !s.closed {
+		s.closed = true
+		close(s.entries)
+	}
+	s.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		s.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return true
+	case <-time.After(timeout):
+		return false
+	}
+}
+
+// RequestLogger - логгер одного запроса: AddFuncName не затрагивает другие запросы,
+// а к каждому сообщению дописываются поля запроса
+type RequestLogger struct {
+	sink   *LogSink
+	fields string
+
+	mu       sync.Mutex
+	funcName string
+}
+
+func (l *RequestLogger) AddFuncName(name string) {
+	l.mu.Lock()
+	l.funcName = name
+	l.mu.Unlock()
+}
+
+func (l *RequestLogger) Info(msg string) {
+	l.log(levelInfo, msg)
+}
+
+func (l *RequestLogger) Error(msg string) {
+	l.log(levelError, msg)
+}
+
+func (l *RequestLogger) Warn(msg string) {
+	l.log(levelWarn, msg)
+}
+
+func (l *RequestLogger) log(level, msg string) {
+	l.mu.Lock()
+	funcName := l.funcName
+	l.mu.Unlock()
+
+	l.sink.send(logEntry{level: level, funcName: funcName, msg: msg + l.fields})
+}
+
+// Logger кладёт в контекст логгер запроса с методом, request id, uuid вызывающего и trace id
+// и после ответа пишет access-лог с длительностью и кодом ответа
+func Logger(cfg *config.Config, sink *LogSink) grpc.UnaryServerInterceptor {
 	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
-		ctx = context.WithValue(ctx, config.KeyLogger, logger)
-		return handler(ctx, req)
+		start := time.Now()
+		method := methodName(info.FullMethod)
+
+		requestID := incomingRequestID(ctx)
+		_ = grpc.SetHeader(ctx, metadata.Pairs(requestIDHeader, requestID))
+
+		logger := &RequestLogger{sink: sink, fields: requestFields(ctx, method, requestID)}
+		logger.AddFuncName(method)
+
+		resp, err := handler(context.WithValue(ctx, config.KeyLogger, logger), req)
+
+		code := status.Code(err)
+		access := fmt.Sprintf("access: status=%s duration=%s request=%s", code, time.Since(start), formatRequest(req, cfg.Logger.Level))
+		if isServerError(code) {
+			logger.Error(access)
+		} else {
+			logger.Info(access)
+		}
+
+		return resp, err
+	}
+}
+
+// withRequestID дописывает request id во входящие метаданные, если клиент его не прислал,
+// чтобы все перехватчики цепочки логировали один и тот же id
+func withRequestID(ctx context.Context) (context.Context, string) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	if ids := md.Get(requestIDHeader); len(ids) == 1 && ids[0] != "" {
+		return ctx, ids[0]
+	}
+
+	requestID := uuid.NewString()
+	md = md.Copy()
+	md.Set(requestIDHeader, requestID)
+	return metadata.NewIncomingContext(ctx, md), requestID
+}
+
+func incomingRequestID(ctx context.Context) string {
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if ids := md.Get(requestIDHeader); len(ids) == 1 && ids[0] != "" {
+			return ids[0]
+		}
+	}
+	return uuid.NewString()
+}
+
+func requestFields(ctx context.Context, method, requestID string) string {
+	fields := []string{"method=" + method, "request_id=" + requestID}
+	if caller, ok := ctx.Value(config.KeyUUID).(string); ok {
+		fields = append(fields, "uuid="+caller)
+	}
+	if spanCtx := trace.SpanContextFromContext(ctx); spanCtx.HasTraceID() {
+		fields = append(fields, "trace_id="+spanCtx.TraceID().String())
+	}
+	return " [" + strings.Join(fields, " ") + "]"
+}
+
+// formatRequest сериализует запрос для access-лога, скрывая текст объявлений выше уровня debug
+func formatRequest(req interface{}, level string) string {
+	msg, ok := req.(proto.Message)
+	if !ok {
+		return "-"
+	}
+	if level != LevelDebug {
+		msg = proto.Clone(msg)
+		redact(msg.ProtoReflect())
+	}
+	return protojson.MarshalOptions{}.Format(msg)
+}
+
+func redact(msg protoreflect.Message) {
+	msg.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		switch {
+		case fd.Kind() == protoreflect.StringKind && !fd.IsList() && !fd.IsMap():
+			if _, ok := redactedFields[fd.Name()]; ok {
+				msg.Set(fd, protoreflect.ValueOfString(fmt.Sprintf("[redacted %d chars]", len([]rune(v.String())))))
+			}
+		case fd.Kind() == protoreflect.MessageKind && fd.IsList():
+			list := v.List()
+			for i := 0; i < list.Len(); i++ {
+				redact(list.Get(i).Message())
+			}
+		case fd.Kind() == protoreflect.MessageKind && !fd.IsMap():
+			redact(v.Message())
+		}
+		return true
+	})
+}
+
+// isServerError отделяет ошибки сервиса от ошибок клиента, чтобы access-лог писался уровнем error только для первых
+func isServerError(code codes.Code) bool {
+	switch code {
+	case codes.Unknown, codes.Internal, codes.DataLoss, codes.Unavailable, codes.DeadlineExceeded, codes.Unimplemented:
+		return true
 	}
+	return false
 }
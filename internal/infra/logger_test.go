@@ -0,0 +1,279 @@
+package infra
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	logger_lib "github.com/s21platform/logger-lib"
+	pkg "github.com/s21platform/metrics-lib/pkg"
+
+	"github.com/s21platform/advert-service/internal/config"
+	advert_api "github.com/s21platform/advert-service/pkg/advert"
+)
+
+func TestFormatRequest(t *testing.T) {
+	t.Parallel()
+
+	in := &advert_api.CreateAdvertIn{
+		Title:       "Продам гараж",
+		TextContent: "секретный текст",
+		User:        &advert_api.UserFilter{Os: []int64{1}},
+	}
+
+	t.Run("redacted", func(t *testing.T) {
+		formatted := formatRequest(in, "info")
+
+		assert.Contains(t, formatted, "[redacted 12 chars]")
+		assert.Contains(t, formatted, "[redacted 15 chars]")
+		assert.NotContains(t, formatted, "гараж")
+		assert.NotContains(t, formatted, "секретный")
+		assert.Contains(t, formatted, "os")
+		// запрос хендлера не меняется
+		assert.Equal(t, "Продам гараж", in.Title)
+	})
+
+	t.Run("debug", func(t *testing.T) {
+		formatted := formatRequest(in, LevelDebug)
+
+		assert.Contains(t, formatted, "Продам гараж")
+		assert.Contains(t, formatted, "секретный текст")
+	})
+
+	t.Run("not_proto", func(t *testing.T) {
+		assert.Equal(t, "-", formatRequest(struct{}{}, "info"))
+	})
+}
+
+func TestRedact(t *testing.T) {
+	t.Parallel()
+
+	out := &advert_api.GetAdvertsOut{Adverts: []*advert_api.AdvertText{
+		{Id: 1, Title: "первое", TextContent: "текст", BanReason: "спам"},
+		{Id: 2, Title: "второе"},
+	}}
+
+	redact(out.ProtoReflect())
+
+	assert.Equal(t, "[redacted 6 chars]", out.Adverts[0].Title)
+	assert.Equal(t, "[redacted 5 chars]", out.Adverts[0].TextContent)
+	assert.Equal(t, "спам", out.Adverts[0].BanReason)
+	assert.Equal(t, "[redacted 6 chars]", out.Adverts[1].Title)
+	assert.Empty(t, out.Adverts[1].TextContent)
+}
+
+func TestLogSink(t *testing.T) {
+	t.Parallel()
+
+	t.Run("delivers_with_func_name", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockLogger := logger_lib.NewMockLoggerInterface(ctrl)
+		mockMetrics := pkg.NewMockMetricInterface(ctrl)
+
+		gomock.InOrder(
+			mockLogger.EXPECT().AddFuncName("GetAdvert"),
+			mockLogger.EXPECT().Error("failed to get advert [request_id=1]"),
+		)
+
+		sink := newLogSink(1, 1, mockMetrics, func() logger_lib.LoggerInterface { return mockLogger })
+		logger := &RequestLogger{sink: sink, fields: " [request_id=1]"}
+		logger.AddFuncName("GetAdvert")
+		logger.Error("failed to get advert")
+
+		assert.True(t, sink.Close(time.Second))
+	})
+
+	t.Run("drops_when_full", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockLogger := logger_lib.NewMockLoggerInterface(ctrl)
+		mockMetrics := pkg.NewMockMetricInterface(ctrl)
+
+		// воркер висит на первой записи, как на зависшем loki
+		release := make(chan struct{})
+		var once sync.Once
+		started := make(chan struct{})
+		mockLogger.EXPECT().AddFuncName(gomock.Any()).AnyTimes()
+		mockLogger.EXPECT().Info(gomock.Any()).Do(func(string) {
+			once.Do(func() { close(started) })
+			<-release
+		}).Times(2)
+		mockMetrics.EXPECT().Increment("logger.dropped")
+
+		sink := newLogSink(1, 1, mockMetrics, func() logger_lib.LoggerInterface { return mockLogger })
+		logger := &RequestLogger{sink: sink}
+
+		logger.Info("first")
+		<-started
+		logger.Info("queued")
+		logger.Info("dropped")
+
+		close(release)
+		assert.True(t, sink.Close(time.Second))
+	})
+
+	t.Run("after_close", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockMetrics := pkg.NewMockMetricInterface(ctrl)
+		mockMetrics.EXPECT().Increment("logger.dropped")
+
+		sink := newLogSink(1, 1, mockMetrics, func() logger_lib.LoggerInterface { return logger_lib.NewMockLoggerInterface(ctrl) })
+		assert.True(t, sink.Close(time.Second))
+
+		(&RequestLogger{sink: sink}).Info("late")
+	})
+}
+
+type logRecord struct {
+	level    string
+	funcName string
+	msg      string
+}
+
+// recordingLogger собирает записи, которые LogSink отправил бы в loki
+type recordingLogger struct {
+	mu       sync.Mutex
+	funcName string
+	records  []logRecord
+}
+
+func (l *recordingLogger) AddFuncName(name string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.funcName = name
+}
+
+func (l *recordingLogger) Info(msg string)  { l.record(levelInfo, msg) }
+func (l *recordingLogger) Warn(msg string)  { l.record(levelWarn, msg) }
+func (l *recordingLogger) Error(msg string) { l.record(levelError, msg) }
+
+func (l *recordingLogger) record(level, msg string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.records = append(l.records, logRecord{level: level, funcName: l.funcName, msg: msg})
+}
+
+// headerStream запоминает заголовки ответа, выставленные через grpc.SetHeader
+type headerStream struct {
+	grpc.ServerTransportStream
+	header metadata.MD
+}
+
+func (s *headerStream) SetHeader(md metadata.MD) error {
+	s.header = metadata.Join(s.header, md)
+	return nil
+}
+
+func TestLogger(t *testing.T) {
+	t.Parallel()
+
+	cfg := &config.Config{}
+	cfg.Logger.Level = "info"
+	info := &grpc.UnaryServerInfo{FullMethod: "/advert.AdvertService/GetAdvert"}
+
+	// run пропускает запрос через Logger и возвращает записи лога и заголовки ответа
+	run := func(t *testing.T, ctx context.Context, handler grpc.UnaryHandler) ([]logRecord, metadata.MD) {
+		t.Helper()
+
+		recorder := &recordingLogger{}
+		sink := newLogSink(8, 1, NewRecorder(nil, false), func() logger_lib.LoggerInterface { return recorder })
+		stream := &headerStream{}
+
+		_, _ = Logger(cfg, sink)(grpc.NewContextWithServerTransportStream(ctx, stream), &advert_api.GetAdvertIn{Id: 1}, info, handler)
+		assert.True(t, sink.Close(time.Second))
+		return recorder.records, stream.header
+	}
+
+	t.Run("request_fields", func(t *testing.T) {
+		traceID, err := trace.TraceIDFromHex("4bf92f3577b34da6a3ce929d0e0e4736")
+		assert.NoError(t, err)
+		spanID, err := trace.SpanIDFromHex("00f067aa0ba902b7")
+		assert.NoError(t, err)
+
+		ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(requestIDHeader, "req-1"))
+		ctx = context.WithValue(ctx, config.KeyUUID, "user-1")
+		ctx = trace.ContextWithSpanContext(ctx, trace.NewSpanContext(trace.SpanContextConfig{TraceID: traceID, SpanID: spanID}))
+
+		records, header := run(t, ctx, func(context.Context, interface{}) (interface{}, error) {
+			return nil, nil
+		})
+
+		assert.Equal(t, []string{"req-1"}, header.Get(requestIDHeader))
+		assert.Len(t, records, 1)
+		assert.Equal(t, "GetAdvert", records[0].funcName)
+		assert.Contains(t, records[0].msg, "status=OK")
+		assert.Contains(t, records[0].msg, "request_id=req-1")
+		assert.Contains(t, records[0].msg, "uuid=user-1")
+		assert.Contains(t, records[0].msg, "trace_id="+traceID.String())
+	})
+
+	t.Run("generates_request_id", func(t *testing.T) {
+		records, header := run(t, context.Background(), func(context.Context, interface{}) (interface{}, error) {
+			return nil, nil
+		})
+
+		ids := header.Get(requestIDHeader)
+		assert.Len(t, ids, 1)
+		_, err := uuid.Parse(ids[0])
+		assert.NoError(t, err)
+		assert.Contains(t, records[0].msg, "request_id="+ids[0])
+	})
+
+	levels := []struct {
+		code codes.Code
+		want string
+	}{
+		{code: codes.Internal, want: levelError},
+		{code: codes.Unavailable, want: levelError},
+		{code: codes.InvalidArgument, want: levelInfo},
+		{code: codes.NotFound, want: levelInfo},
+		{code: codes.PermissionDenied, want: levelInfo},
+	}
+	for _, tt := range levels {
+		t.Run("level_"+tt.code.String(), func(t *testing.T) {
+			records, _ := run(t, context.Background(), func(context.Context, interface{}) (interface{}, error) {
+				return nil, status.Error(tt.code, "failed")
+			})
+
+			assert.Len(t, records, 1)
+			assert.Equal(t, tt.want, records[0].level)
+			assert.Contains(t, records[0].msg, "status="+tt.code.String())
+		})
+	}
+
+	t.Run("logger_per_request", func(t *testing.T) {
+		recorder := &recordingLogger{}
+		sink := newLogSink(8, 1, NewRecorder(nil, false), func() logger_lib.LoggerInterface { return recorder })
+		interceptor := Logger(cfg, sink)
+
+		var loggers []interface{}
+		for _, id := range []string{"req-1", "req-2"} {
+			ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(requestIDHeader, id))
+			_, _ = interceptor(ctx, nil, info, func(ctx context.Context, _ interface{}) (interface{}, error) {
+				logger := ctx.Value(config.KeyLogger)
+				loggers = append(loggers, logger)
+				logger.(logger_lib.LoggerInterface).AddFuncName("handler-" + id)
+				return nil, nil
+			})
+		}
+		assert.True(t, sink.Close(time.Second))
+
+		// общий логгер гонялся бы за AddFuncName между запросами
+		assert.IsType(t, &RequestLogger{}, loggers[0])
+		assert.NotSame(t, loggers[0], loggers[1])
+		assert.Len(t, recorder.records, 2)
+		assert.Equal(t, "handler-req-1", recorder.records[0].funcName)
+		assert.Contains(t, recorder.records[0].msg, "request_id=req-1")
+		assert.Equal(t, "handler-req-2", recorder.records[1].funcName)
+		assert.Contains(t, recorder.records[1].msg, "request_id=req-2")
+	})
+}
@@ -0,0 +1,144 @@
+package infra
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+
+	pkg "github.com/s21platform/metrics-lib/pkg"
+
+	"github.com/s21platform/advert-service/internal/config"
+)
+
+// Recorder пишет метрики в graphite через metrics-lib и, если включено, в prometheus.
+// Реализует pkg.MetricInterface, поэтому кладётся в контекст запроса вместо клиента metrics-lib
+type Recorder struct {
+	graphite *pkg.Metrics
+	registry *prometheus.Registry
+
+	rpcHandled *prometheus.CounterVec
+	rpcLatency *prometheus.HistogramVec
+	counters   *prometheus.CounterVec
+	durations  *prometheus.HistogramVec
+	gauges     *prometheus.GaugeVec
+}
+
+// NewRecorder принимает nil вместо graphite, если до него не удалось достучаться
+func NewRecorder(graphite *pkg.Metrics, withPrometheus bool) *Recorder {
+	r := &Recorder{graphite: graphite}
+	if !withPrometheus {
+		return r
+	}
+
+	r.registry = prometheus.NewRegistry()
+	r.rpcHandled = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "grpc_server_handled_total",
+		Help: "Number of RPCs completed on the server by method and status code.",
+	}, []string{"method", "code"})
+	r.rpcLatency = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "grpc_server_handling_seconds",
+		Help:    "RPC latency on the server by method.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"method"})
+	r.counters = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "advert_events_total",
+		Help: "Business and infrastructure counters by metric name.",
+	}, []string{"name"})
+	r.durations = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "advert_duration_seconds",
+		Help:    "Timings such as repository queries by metric name.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"name"})
+	r.gauges = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "advert_gauge",
+		Help: "Gauges by metric name.",
+	}, []string{"name"})
+
+	r.registry.MustRegister(
+		r.rpcHandled, r.rpcLatency, r.counters, r.durations, r.gauges,
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+	)
+	return r
+}
+
+func (r *Recorder) Increment(name string) {
+	r.Count(name, 1)
+}
+
+func (r *Recorder) Count(name string, value int64) {
+	if r.graphite != nil {
+		r.graphite.Count(name, value)
+	}
+	if r.registry != nil {
+		r.counters.WithLabelValues(name).Add(float64(value))
+	}
+}
+
+func (r *Recorder) Gauge(name string, value float64) {
+	if r.graphite != nil {
+		r.graphite.Gauge(name, value)
+	}
+	if r.registry != nil {
+		r.gauges.WithLabelValues(name).Set(value)
+	}
+}
+
+// Duration принимает длительность в миллисекундах, как metrics-lib
+func (r *Recorder) Duration(timestamp int64, name string) {
+	if r.graphite != nil {
+		r.graphite.Duration(timestamp, name)
+	}
+	if r.registry != nil {
+		r.durations.WithLabelValues(name).Observe(float64(timestamp) / 1000)
+	}
+}
+
+func (r *Recorder) observeRPC(method, code string, elapsed time.Duration) {
+	if r.graphite != nil {
+		r.graphite.Increment("grpc." + method + "." + code)
+		r.graphite.Duration(elapsed.Milliseconds(), "grpc."+method)
+	}
+	if r.registry != nil {
+		r.rpcHandled.WithLabelValues(method, code).Inc()
+		r.rpcLatency.WithLabelValues(method).Observe(elapsed.Seconds())
+	}
+}
+
+// Handler отдаёт метрики в формате prometheus; nil, если prometheus выключен
+func (r *Recorder) Handler() http.Handler {
+	if r.registry == nil {
+		return nil
+	}
+	return promhttp.HandlerFor(r.registry, promhttp.HandlerOpts{})
+}
+
+func (r *Recorder) Close() {
+	if r.graphite != nil {
+		r.graphite.Disconnect()
+	}
+}
+
+// MetricsInterceptor считает RPC по методу и коду ответа и кладёт Recorder в контекст для бизнес-счётчиков
+func MetricsInterceptor(r *Recorder) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		start := time.Now()
+
+		resp, err := handler(context.WithValue(ctx, config.KeyMetrics, r), req)
+
+		r.observeRPC(methodName(info.FullMethod), status.Code(err).String(), time.Since(start))
+		return resp, err
+	}
+}
+
+// methodName оставляет от /advert.AdvertService/GetAdvert только GetAdvert
+func methodName(fullMethod string) string {
+	return fullMethod[strings.LastIndex(fullMethod, "/")+1:]
+}
@@ -0,0 +1,73 @@
+package infra
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pkg "github.com/s21platform/metrics-lib/pkg"
+
+	"github.com/s21platform/advert-service/internal/config"
+)
+
+func scrape(t *testing.T, r *Recorder) string {
+	t.Helper()
+
+	rec := httptest.NewRecorder()
+	r.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	body, err := io.ReadAll(rec.Body)
+	assert.NoError(t, err)
+	return string(body)
+}
+
+func TestMetricsInterceptor(t *testing.T) {
+	t.Parallel()
+
+	r := NewRecorder(nil, true)
+	interceptor := MetricsInterceptor(r)
+	info := &grpc.UnaryServerInfo{FullMethod: "/advert.AdvertService/GetAdvert"}
+
+	_, err := interceptor(context.Background(), nil, info, func(ctx context.Context, _ interface{}) (interface{}, error) {
+		metrics, ok := ctx.Value(config.KeyMetrics).(pkg.MetricInterface)
+		assert.True(t, ok)
+		metrics.Increment("advert.created")
+		return nil, nil
+	})
+	assert.NoError(t, err)
+
+	_, err = interceptor(context.Background(), nil, info, func(context.Context, interface{}) (interface{}, error) {
+		return nil, status.Error(codes.NotFound, "advert not found")
+	})
+	assert.Error(t, err)
+
+	body := scrape(t, r)
+	assert.Contains(t, body, `grpc_server_handled_total{code="OK",method="GetAdvert"} 1`)
+	assert.Contains(t, body, `grpc_server_handled_total{code="NotFound",method="GetAdvert"} 1`)
+	assert.Contains(t, body, `grpc_server_handling_seconds_count{method="GetAdvert"} 2`)
+	assert.Contains(t, body, `advert_events_total{name="advert.created"} 1`)
+}
+
+func TestRecorder_WithoutPrometheus(t *testing.T) {
+	t.Parallel()
+
+	r := NewRecorder(nil, false)
+	r.Increment("advert.created")
+	r.Duration(10, "db.GetAdvert")
+	r.Gauge("outbox.pending", 3)
+
+	assert.Nil(t, r.Handler())
+}
+
+func TestMethodName(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "GetAdvert", methodName("/advert.AdvertService/GetAdvert"))
+	assert.Equal(t, "Check", methodName("/grpc.health.v1.Health/Check"))
+}
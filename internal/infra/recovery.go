@@ -0,0 +1,60 @@
+package infra
+
+import (
+	"context"
+	"fmt"
+	"runtime/debug"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const panicMetric = "grpc.panic"
+
+// RecoveryInterceptor превращает панику в codes.Internal вместо падения процесса.
+// Ставится первым в цепочке, чтобы ловить паники и в остальных перехватчиках
+func RecoveryInterceptor(sink *LogSink, r *Recorder) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
+		start := time.Now()
+		ctx, requestID := withRequestID(ctx)
+
+		defer func() {
+			if p := recover(); p != nil {
+				err = recovered(ctx, sink, r, info.FullMethod, requestID, start, p)
+			}
+		}()
+
+		return handler(ctx, req)
+	}
+}
+
+func StreamRecoveryInterceptor(sink *LogSink, r *Recorder) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+		start := time.Now()
+
+		defer func() {
+			if p := recover(); p != nil {
+				ctx := ss.Context()
+				err = recovered(ctx, sink, r, info.FullMethod, incomingRequestID(ctx), start, p)
+			}
+		}()
+
+		return handler(srv, ss)
+	}
+}
+
+// recovered пишет стек и считает вызов: паника проходит мимо MetricsInterceptor и access-лога
+func recovered(ctx context.Context, sink *LogSink, r *Recorder, fullMethod, requestID string, start time.Time, p interface{}) error {
+	method := methodName(fullMethod)
+
+	r.Increment(panicMetric)
+	r.observeRPC(method, codes.Internal.String(), time.Since(start))
+
+	logger := &RequestLogger{sink: sink, fields: requestFields(ctx, method, requestID)}
+	logger.AddFuncName(method)
+	logger.Error(fmt.Sprintf("panic: %v\n%s", p, debug.Stack()))
+
+	return status.Error(codes.Internal, "internal error")
+}
@@ -0,0 +1,115 @@
+package infra
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	logger_lib "github.com/s21platform/logger-lib"
+)
+
+type testServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *testServerStream) Context() context.Context {
+	return s.ctx
+}
+
+// recoverySink собирает записи о паниках в канал
+func recoverySink(t *testing.T) (*LogSink, <-chan string) {
+	t.Helper()
+
+	ctrl := gomock.NewController(t)
+	mockLogger := logger_lib.NewMockLoggerInterface(ctrl)
+	logged := make(chan string, 1)
+	mockLogger.EXPECT().AddFuncName(gomock.Any()).AnyTimes()
+	mockLogger.EXPECT().Error(gomock.Any()).Do(func(msg string) { logged <- msg })
+
+	return newLogSink(1, 1, NewRecorder(nil, false), func() logger_lib.LoggerInterface { return mockLogger }), logged
+}
+
+func TestRecoveryInterceptor(t *testing.T) {
+	t.Parallel()
+
+	info := &grpc.UnaryServerInfo{FullMethod: "/advert.AdvertService/EditAdvert"}
+
+	t.Run("panic", func(t *testing.T) {
+		sink, logged := recoverySink(t)
+		r := NewRecorder(nil, true)
+		ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(requestIDHeader, "req-1"))
+
+		resp, err := RecoveryInterceptor(sink, r)(ctx, nil, info, func(context.Context, interface{}) (interface{}, error) {
+			var filter *struct{ Os []int64 }
+			return filter.Os, nil
+		})
+
+		assert.Nil(t, resp)
+		assert.Equal(t, codes.Internal, status.Code(err))
+		assert.True(t, sink.Close(time.Second))
+
+		msg := <-logged
+		assert.Contains(t, msg, "panic: runtime error: invalid memory address or nil pointer dereference")
+		assert.Contains(t, msg, "recovery_test.go")
+		assert.Contains(t, msg, "method=EditAdvert request_id=req-1")
+
+		body := scrape(t, r)
+		assert.Contains(t, body, `advert_events_total{name="grpc.panic"} 1`)
+		assert.Contains(t, body, `grpc_server_handled_total{code="Internal",method="EditAdvert"} 1`)
+	})
+
+	t.Run("panic_in_inner_interceptor", func(t *testing.T) {
+		sink, logged := recoverySink(t)
+		inner := func(ctx context.Context, req interface{}, _ *grpc.UnaryServerInfo, _ grpc.UnaryHandler) (interface{}, error) {
+			panic("auth failed hard")
+		}
+
+		_, err := RecoveryInterceptor(sink, NewRecorder(nil, false))(context.Background(), nil, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+			return inner(ctx, req, info, nil)
+		})
+
+		assert.Equal(t, codes.Internal, status.Code(err))
+		assert.True(t, sink.Close(time.Second))
+		assert.Contains(t, <-logged, "panic: auth failed hard")
+	})
+
+	t.Run("request_id_passed_down", func(t *testing.T) {
+		sink := newLogSink(1, 1, NewRecorder(nil, false), func() logger_lib.LoggerInterface { return nil })
+		defer sink.Close(time.Second)
+
+		resp, err := RecoveryInterceptor(sink, NewRecorder(nil, false))(context.Background(), "req", info, func(ctx context.Context, req interface{}) (interface{}, error) {
+			md, _ := metadata.FromIncomingContext(ctx)
+			assert.Len(t, md.Get(requestIDHeader), 1)
+			return req, nil
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "req", resp)
+	})
+}
+
+func TestStreamRecoveryInterceptor(t *testing.T) {
+	t.Parallel()
+
+	sink, logged := recoverySink(t)
+	r := NewRecorder(nil, true)
+	stream := &testServerStream{ctx: metadata.NewIncomingContext(context.Background(), metadata.Pairs(requestIDHeader, "req-2"))}
+	info := &grpc.StreamServerInfo{FullMethod: "/advert.AdvertService/WatchAdverts"}
+
+	err := StreamRecoveryInterceptor(sink, r)(nil, stream, info, func(interface{}, grpc.ServerStream) error {
+		panic("stream broke")
+	})
+
+	assert.Equal(t, codes.Internal, status.Code(err))
+	assert.True(t, sink.Close(time.Second))
+	assert.Contains(t, <-logged, "method=WatchAdverts request_id=req-2")
+	assert.Contains(t, scrape(t, r), `advert_events_total{name="grpc.panic"} 1`)
+}
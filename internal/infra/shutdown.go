@@ -0,0 +1,88 @@
+package infra
+
+import (
+	"context"
+	"errors"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+
+	"google.golang.org/grpc"
+)
+
+var ErrShuttingDown = errors.New("shutting down")
+
+// SignalContext отменяется по SIGINT/SIGTERM
+func SignalContext(parent context.Context) (context.Context, context.CancelFunc) {
+	return signal.NotifyContext(parent, os.Interrupt, syscall.SIGTERM)
+}
+
+// GracefulStop дожидается завершения текущих RPC, но не дольше timeout; потом рвёт соединения.
+// Возвращает false, если пришлось остановить сервер принудительно
+func GracefulStop(server *grpc.Server, timeout time.Duration) bool {
+	stopped := make(chan struct{})
+	go func() {
+		server.GracefulStop()
+		close(stopped)
+	}()
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	select {
+	case <-stopped:
+		return true
+	case <-timer.C:
+		server.Stop()
+		return false
+	}
+}
+
+// Drainer отслеживает сообщения в обработке, чтобы при остановке дождаться их, а не обрывать на середине
+type Drainer struct {
+	mu       sync.Mutex
+	draining bool
+	inflight sync.WaitGroup
+}
+
+// Wrap не даёт отмене контекста consumer'а прервать уже начатую обработку;
+// после начала остановки новые сообщения не берутся и остаются незакоммиченными
+func (d *Drainer) Wrap(handler func(context.Context, []byte) error) func(context.Context, []byte) error {
+	return func(ctx context.Context, msg []byte) error {
+		d.mu.Lock()
+		if d.draining {
+			d.mu.Unlock()
+			return ErrShuttingDown
+		}
+		d.inflight.Add(1)
+		d.mu.Unlock()
+		defer d.inflight.Done()
+
+		return handler(context.WithoutCancel(ctx), msg)
+	}
+}
+
+// Drain ждёт сообщения в обработке не дольше timeout; возвращает false по таймауту
+func (d *Drainer) Drain(timeout time.Duration) bool {
+	d.mu.Lock()
+	d.draining = true
+	d.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		d.inflight.Wait()
+		close(done)
+	}()
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	select {
+	case <-done:
+		return true
+	case <-timer.C:
+		return false
+	}
+}
@@ -0,0 +1,52 @@
+package infra
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+
+	"github.com/s21platform/advert-service/internal/config"
+)
+
+// ServerTLS включает TLS на gRPC-сервере, если задан сертификат. С ClientCAFile сервер проверяет
+// клиентские сертификаты, но не требует их: клиенты без сертификата аутентифицируются токеном.
+// Без сертификата возвращает nil
+func ServerTLS(cfg config.Auth) (grpc.ServerOption, error) {
+	if cfg.TLSCertFile == "" {
+		if cfg.ClientCAFile != "" || len(cfg.ServiceAccounts) > 0 {
+			return nil, errors.New("mtls requires a server certificate")
+		}
+		return nil, nil
+	}
+
+	cert, err := tls.LoadX509KeyPair(cfg.TLSCertFile, cfg.TLSKeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load server certificate: %w", err)
+	}
+	tlsConfig := &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		MinVersion:   tls.VersionTLS12,
+	}
+
+	if cfg.ClientCAFile != "" {
+		caPEM, err := os.ReadFile(cfg.ClientCAFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read client ca: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(caPEM) {
+			return nil, errors.New("client ca file has no certificates")
+		}
+		tlsConfig.ClientCAs = pool
+		tlsConfig.ClientAuth = tls.VerifyClientCertIfGiven
+	} else if len(cfg.ServiceAccounts) > 0 {
+		return nil, errors.New("service accounts require a client ca")
+	}
+
+	return grpc.Creds(credentials.NewTLS(tlsConfig)), nil
+}
@@ -15,6 +15,7 @@ type Advert struct {
 	TextContent string     `db:"text_content"`
 	UserFilter  UserFilter `db:"filter"`
 	ExpiresAt   time.Time  `db:"expired_at"`
+	StartAt     *time.Time `db:"start_at"`
 }
 
 type UserFilter struct {
@@ -29,7 +30,12 @@ func (uf UserFilter) Value() (driver.Value, error) {
 	return string(j), nil
 }
 
-func (uf UserFilter) Scan(value interface{}) error {
+func (uf *UserFilter) Scan(value interface{}) error {
+	if value == nil {
+		*uf = UserFilter{}
+		return nil
+	}
+
 	b, isBytes := value.([]byte)
 	if !isBytes {
 		s, isString := value.(string)
@@ -39,16 +45,20 @@ func (uf UserFilter) Scan(value interface{}) error {
 		b = []byte(s)
 	}
 
-	return json.Unmarshal(b, &uf)
+	return json.Unmarshal(b, uf)
 }
 
 func (a *Advert) AdvertToDTO(UUID string, in *advert_api.CreateAdvertIn) (Advert, error) {
 	result := Advert{
 		OwnerUUID:   UUID,
-		Title:       in.Title,
-		TextContent: in.TextContent,
-		UserFilter:  UserFilter{Os: in.User.Os},
-		ExpiresAt:   in.ExpiredAt.AsTime(),
+		Title:       in.GetTitle(),
+		TextContent: in.GetTextContent(),
+		UserFilter:  UserFilter{Os: in.GetUser().GetOs()},
+		ExpiresAt:   in.GetExpiredAt().AsTime(),
+	}
+	if in.GetStartAt() != nil {
+		startAt := in.GetStartAt().AsTime()
+		result.StartAt = &startAt
 	}
 
 	return result, nil
@@ -0,0 +1,92 @@
+package model
+
+import (
+	"context"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+)
+
+const (
+	EventAdvertCreated       = "advert.created"
+	EventAdvertEdited        = "advert.edited"
+	EventAdvertStatusChanged = "advert.status_changed"
+	EventAdvertExtended      = "advert.extended"
+)
+
+// AdvertEvent - доменное событие, которое записывается в outbox вместе с изменением объявления
+type AdvertEvent struct {
+	Type           string       `json:"type"`
+	AdvertID       int64        `json:"advert_id"`
+	OwnerUUID      string       `json:"owner_uuid"`
+	Version        int64        `json:"version"`
+	Status         AdvertStatus `json:"status,omitempty"`
+	PreviousStatus AdvertStatus `json:"previous_status,omitempty"`
+	ExpiredAt      *time.Time   `json:"expired_at,omitempty"`
+	OccurredAt     time.Time    `json:"occurred_at"`
+}
+
+// OutboxEvent - строка outbox, ожидающая отправки в kafka
+type OutboxEvent struct {
+	ID           int64        `db:"id"`
+	AdvertID     int64        `db:"advert_id"`
+	Type         string       `db:"event_type"`
+	Payload      []byte       `db:"payload"`
+	Attempts     int          `db:"attempts"`
+	TraceContext TraceContext `db:"trace_context"`
+}
+
+// TraceContext - заголовки trace context запроса, в котором событие записано в outbox
+type TraceContext map[string]string
+
+// NewTraceContext сохраняет trace из ctx, чтобы relay продолжил trace запроса, изменившего объявление
+func NewTraceContext(ctx context.Context) TraceContext {
+	t := TraceContext{}
+	otel.GetTextMapPropagator().Inject(ctx, propagation.MapCarrier(t))
+	return t
+}
+
+// Extract возвращает ctx с trace, сохранённым NewTraceContext
+func (t TraceContext) Extract(ctx context.Context) context.Context {
+	return otel.GetTextMapPropagator().Extract(ctx, propagation.MapCarrier(t))
+}
+
+func (t TraceContext) Value() (driver.Value, error) {
+	if len(t) == 0 {
+		return nil, nil
+	}
+	j, err := json.Marshal(t)
+	if err != nil {
+		return nil, err
+	}
+	return string(j), nil
+}
+
+func (t *TraceContext) Scan(value interface{}) error {
+	if value == nil {
+		*t = nil
+		return nil
+	}
+
+	b, isBytes := value.([]byte)
+	if !isBytes {
+		s, isString := value.(string)
+		if !isString {
+			return errors.New("failed to Scan trace_context field, supported types: `string` or `[]byte`")
+		}
+		b = []byte(s)
+	}
+
+	return json.Unmarshal(b, t)
+}
+
+// PublishOutboxFunc отправляет пачку событий и возвращает ошибку для каждого из них по порядку;
+// событие с ошибкой откладывается до следующей попытки
+type PublishOutboxFunc func(ctx context.Context, events []OutboxEvent) []error
+
+// BackoffFunc возвращает задержку перед попыткой номер attempts
+type BackoffFunc func(attempts int) time.Duration
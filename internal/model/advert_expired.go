@@ -0,0 +1,27 @@
+package model
+
+import (
+	"context"
+	"time"
+)
+
+// AdvertExpired - событие advert.expired о переводе объявления в статус expired
+type AdvertExpired struct {
+	ID        int64     `db:"id" json:"advert_id"`
+	OwnerUUID string    `db:"owner_uuid" json:"owner_uuid"`
+	ExpiredAt time.Time `db:"expired_at" json:"expired_at"`
+}
+
+// PublishExpiredFunc отправляет события до фиксации транзакции, чтобы они не терялись
+type PublishExpiredFunc func(ctx context.Context, adverts []AdvertExpired) error
+
+// AdvertExpiryReminder - напоминание владельцу о скором истечении объявления
+type AdvertExpiryReminder struct {
+	ID        int64     `db:"id" json:"advert_id"`
+	OwnerUUID string    `db:"owner_uuid" json:"owner_uuid"`
+	ExpiredAt time.Time `db:"expired_at" json:"expired_at"`
+	HoursLeft int64     `db:"hours_left" json:"hours_left"`
+}
+
+// PublishRemindersFunc отправляет напоминания до фиксации отметки об их отправке
+type PublishRemindersFunc func(ctx context.Context, reminders []AdvertExpiryReminder) error
@@ -0,0 +1,22 @@
+package model
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	ErrAdvertNotExtendable = errors.New("advert can not be extended in its current status")
+	ErrRenewalWindowClosed = errors.New("advert renewal window is closed")
+	ErrExpiryNotLater      = errors.New("new expiry must be later than the current one")
+	ErrMaxLifetimeExceeded = errors.New("advert lifetime limit exceeded")
+)
+
+// Extension - новый срок объявления; Renew означает возврат истёкшего объявления в active
+type Extension struct {
+	ExpiredAt time.Time
+	Renew     bool
+}
+
+// ExtendFunc рассчитывает продление по заблокированному состоянию объявления
+type ExtendFunc func(advert *AdvertLifecycle) (*Extension, error)
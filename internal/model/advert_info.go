@@ -11,19 +11,38 @@ import (
 type AdvertInfoList []*AdvertInfo
 
 type AdvertInfo struct {
-	ID        int64     `db:"id"`
-	Title     string    `db:"title"`
-	Content   string    `db:"text_content"`
-	ExpiredAt time.Time `db:"expired_at"`
+	ID        int64        `db:"id"`
+	OwnerUUID string       `db:"owner_uuid"`
+	Title     string       `db:"title"`
+	Content   string       `db:"text_content"`
+	ExpiredAt time.Time    `db:"expired_at"`
+	CreatedAt time.Time    `db:"created_at"`
+	Status    AdvertStatus `db:"status"`
+	BanReason string       `db:"ban_reason"`
+	Version   int64        `db:"version"`
+	StartAt   *time.Time   `db:"start_at"`
+	Filter    UserFilter   `db:"filter"`
+	OsNames   AttributeNames
 }
 
 func (a *AdvertInfo) FromDTO() *advert_proto.AdvertText {
-	return &advert_proto.AdvertText{
+	result := &advert_proto.AdvertText{
 		Id:          a.ID,
 		Title:       a.Title,
 		TextContent: a.Content,
 		ExpiredAt:   timestamppb.New(a.ExpiredAt),
+		IsBanned:    a.Status == StatusBanned,
+		BanReason:   a.BanReason,
+		Status:      a.Status.ToProto(),
+		Version:     a.Version,
 	}
+	if a.StartAt != nil {
+		result.StartAt = timestamppb.New(*a.StartAt)
+	}
+	for _, id := range a.Filter.Os {
+		result.Os = append(result.Os, &advert_proto.AttributeValue{Id: id, Name: a.OsNames[id]})
+	}
+	return result
 }
 
 func (a *AdvertInfoList) ListFromDTO() []*advert_proto.AdvertText {
@@ -35,3 +54,24 @@ func (a *AdvertInfoList) ListFromDTO() []*advert_proto.AdvertText {
 
 	return result
 }
+
+// OsIDs возвращает os всех объявлений списка без повторов
+func (a *AdvertInfoList) OsIDs() []int64 {
+	seen := make(map[int64]struct{})
+	var ids []int64
+	for _, advert := range *a {
+		for _, id := range advert.Filter.Os {
+			if _, ok := seen[id]; !ok {
+				seen[id] = struct{}{}
+				ids = append(ids, id)
+			}
+		}
+	}
+	return ids
+}
+
+func (a *AdvertInfoList) SetOsNames(names AttributeNames) {
+	for _, advert := range *a {
+		advert.OsNames = names
+	}
+}
@@ -0,0 +1,86 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+var (
+	ErrForbiddenTransition = errors.New("forbidden advert status transition")
+	ErrAdvertNotActive     = errors.New("advert is not active")
+	ErrAdvertNotCanceled   = errors.New("advert is not canceled")
+	ErrAdvertNotBanned     = errors.New("advert is not banned")
+	ErrVersionMismatch     = errors.New("advert version mismatch")
+	ErrAdvertNotPaused     = errors.New("advert is not paused")
+	ErrRestoreWindowClosed = errors.New("advert restore window is closed")
+	ErrAdvertAlreadyLive   = errors.New("start_at can not be changed after publication")
+)
+
+// AdvertLifecycle - состояние объявления, заблокированное на время транзакции
+type AdvertLifecycle struct {
+	ID         int64        `db:"id"`
+	OwnerUUID  string       `db:"owner_uuid"`
+	Status     AdvertStatus `db:"status"`
+	ExpiredAt  *time.Time   `db:"expired_at"`
+	StartAt    *time.Time   `db:"start_at"`
+	CanceledAt *time.Time   `db:"canceled_at"`
+	PausedAt   *time.Time   `db:"paused_at"`
+	CreatedAt  *time.Time   `db:"created_at"`
+	Version    int64        `db:"version"`
+}
+
+// Transition описывает переход в новый статус и сопутствующие изменения
+type Transition struct {
+	To        AdvertStatus
+	ExpiredAt *time.Time
+	BanReason string
+	BannedBy  string
+}
+
+// TransitionFunc решает, в какой статус перевести объявление
+type TransitionFunc func(advert *AdvertLifecycle) (*Transition, error)
+
+// CheckFunc проверяет объявление перед изменением
+type CheckFunc func(advert *AdvertLifecycle) error
+
+// CurrentStatus учитывает истечение срока у активных объявлений, которые ещё не перевели в expired,
+// и ещё не начавшиеся объявления
+func (a *AdvertLifecycle) CurrentStatus(now time.Time) AdvertStatus {
+	if a.Status != StatusActive {
+		return a.Status
+	}
+	if a.ExpiredAt != nil && !a.ExpiredAt.After(now) {
+		return StatusExpired
+	}
+	if a.StartAt != nil && a.StartAt.After(now) {
+		return StatusScheduled
+	}
+	return a.Status
+}
+
+// IsEditable - содержимое можно менять у опубликованных, запланированных и приостановленных объявлений
+func (a *AdvertLifecycle) IsEditable() bool {
+	return a.Status == StatusActive || a.Status == StatusScheduled || a.Status == StatusPaused
+}
+
+// WentLive - объявление уже показывалось: start_at не задан или наступил
+func (a *AdvertLifecycle) WentLive(now time.Time) bool {
+	return a.StartAt == nil || !a.StartAt.After(now)
+}
+
+// LiveSince возвращает момент, с которого объявление было видно пользователям, начиная с from
+func (a *AdvertLifecycle) LiveSince(from time.Time) time.Time {
+	if a.StartAt != nil && a.StartAt.After(from) {
+		return *a.StartAt
+	}
+	return from
+}
+
+// CheckVersion сверяет версию объявления с ожидаемой клиентом; нулевая версия не проверяется
+func (a *AdvertLifecycle) CheckVersion(expected int64) error {
+	if expected != 0 && expected != a.Version {
+		return fmt.Errorf("%w: expected %d, actual %d", ErrVersionMismatch, expected, a.Version)
+	}
+	return nil
+}
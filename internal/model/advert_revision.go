@@ -0,0 +1,63 @@
+package model
+
+import (
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	advert_proto "github.com/s21platform/advert-service/pkg/advert"
+)
+
+type AdvertRevisionList []*AdvertRevision
+
+// AdvertRevision - содержимое объявления, которое стало действовать с указанной версии
+type AdvertRevision struct {
+	ID          int64      `db:"id"`
+	AdvertID    int64      `db:"advert_id"`
+	Version     int64      `db:"version"`
+	Title       string     `db:"title"`
+	TextContent string     `db:"text_content"`
+	UserFilter  UserFilter `db:"filter"`
+	ExpiredAt   *time.Time `db:"expired_at"`
+	EditedBy    string     `db:"edited_by"`
+	CreatedAt   time.Time  `db:"created_at"`
+}
+
+func (r *AdvertRevision) FromDTO() *advert_proto.AdvertRevision {
+	result := &advert_proto.AdvertRevision{
+		Id:          r.ID,
+		AdvertId:    r.AdvertID,
+		Version:     r.Version,
+		Title:       r.Title,
+		TextContent: r.TextContent,
+		UserFilter:  &advert_proto.UserFilter{Os: r.UserFilter.Os},
+		EditedBy:    r.EditedBy,
+		CreatedAt:   timestamppb.New(r.CreatedAt),
+	}
+	if r.ExpiredAt != nil {
+		result.ExpiredAt = timestamppb.New(*r.ExpiredAt)
+	}
+	return result
+}
+
+func (l *AdvertRevisionList) ListFromDTO() []*advert_proto.AdvertRevision {
+	result := make([]*advert_proto.AdvertRevision, 0, len(*l))
+
+	for _, revision := range *l {
+		result = append(result, revision.FromDTO())
+	}
+
+	return result
+}
+
+// ToEdit готовит правку, возвращающую содержимое ревизии; срок действия не откатывается
+func (r *AdvertRevision) ToEdit(editedBy string) *EditAdvert {
+	return &EditAdvert{
+		ID:          r.AdvertID,
+		Title:       r.Title,
+		TextContent: r.TextContent,
+		UserFilter:  r.UserFilter,
+		Fields:      []string{EditFieldTitle, EditFieldTextContent, EditFieldUserFilter},
+		EditedBy:    editedBy,
+	}
+}
@@ -0,0 +1,65 @@
+package model
+
+import advert_api "github.com/s21platform/advert-service/pkg/advert"
+
+type AdvertStatus string
+
+const (
+	StatusDraft         AdvertStatus = "draft"
+	StatusPendingReview AdvertStatus = "pending_review"
+	StatusActive        AdvertStatus = "active"
+	StatusPaused        AdvertStatus = "paused"
+	StatusCanceled      AdvertStatus = "canceled"
+	StatusBanned        AdvertStatus = "banned"
+	StatusExpired       AdvertStatus = "expired"
+	StatusArchived      AdvertStatus = "archived"
+	// StatusScheduled не хранится в базе: так выглядит active до наступления start_at
+	StatusScheduled AdvertStatus = "scheduled"
+)
+
+// transitions - допустимые переходы между статусами объявления
+var transitions = map[AdvertStatus][]AdvertStatus{
+	StatusDraft:         {StatusPendingReview, StatusActive, StatusArchived},
+	StatusPendingReview: {StatusDraft, StatusActive, StatusBanned, StatusArchived},
+	StatusActive:        {StatusPaused, StatusCanceled, StatusBanned, StatusExpired, StatusArchived},
+	StatusPaused:        {StatusActive, StatusCanceled, StatusBanned, StatusArchived},
+	StatusCanceled:      {StatusActive, StatusBanned, StatusExpired, StatusArchived},
+	StatusBanned:        {StatusActive, StatusArchived},
+	StatusExpired:       {StatusActive, StatusBanned, StatusArchived},
+	StatusArchived:      {},
+	StatusScheduled:     {StatusPaused, StatusCanceled, StatusBanned, StatusArchived},
+}
+
+var statusToProto = map[AdvertStatus]advert_api.AdvertStatus{
+	StatusDraft:         advert_api.AdvertStatus_ADVERT_STATUS_DRAFT,
+	StatusPendingReview: advert_api.AdvertStatus_ADVERT_STATUS_PENDING_REVIEW,
+	StatusActive:        advert_api.AdvertStatus_ADVERT_STATUS_ACTIVE,
+	StatusPaused:        advert_api.AdvertStatus_ADVERT_STATUS_PAUSED,
+	StatusCanceled:      advert_api.AdvertStatus_ADVERT_STATUS_CANCELED,
+	StatusBanned:        advert_api.AdvertStatus_ADVERT_STATUS_BANNED,
+	StatusExpired:       advert_api.AdvertStatus_ADVERT_STATUS_EXPIRED,
+	StatusArchived:      advert_api.AdvertStatus_ADVERT_STATUS_ARCHIVED,
+	StatusScheduled:     advert_api.AdvertStatus_ADVERT_STATUS_SCHEDULED,
+}
+
+func StatusFromProto(in advert_api.AdvertStatus) (AdvertStatus, bool) {
+	for st, proto := range statusToProto {
+		if proto == in {
+			return st, true
+		}
+	}
+	return "", false
+}
+
+func (s AdvertStatus) CanTransitionTo(to AdvertStatus) bool {
+	for _, allowed := range transitions[s] {
+		if allowed == to {
+			return true
+		}
+	}
+	return false
+}
+
+func (s AdvertStatus) ToProto() advert_api.AdvertStatus {
+	return statusToProto[s]
+}
@@ -0,0 +1,145 @@
+package model
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	advert_api "github.com/s21platform/advert-service/pkg/advert"
+)
+
+const (
+	DefaultPageSize = 20
+	MaxPageSize     = 100
+)
+
+var ErrInvalidPageToken = errors.New("invalid page token")
+
+type AdvertSortKey string
+
+const (
+	SortByCreatedAt AdvertSortKey = "created_at"
+	SortByExpiredAt AdvertSortKey = "expired_at"
+	SortByTitle     AdvertSortKey = "title"
+)
+
+var sortKeyFromProto = map[advert_api.AdvertSortKey]AdvertSortKey{
+	advert_api.AdvertSortKey_ADVERT_SORT_KEY_CREATED_AT: SortByCreatedAt,
+	advert_api.AdvertSortKey_ADVERT_SORT_KEY_EXPIRED_AT: SortByExpiredAt,
+	advert_api.AdvertSortKey_ADVERT_SORT_KEY_TITLE:      SortByTitle,
+}
+
+// AdvertsPage - запрос страницы объявлений владельца
+type AdvertsPage struct {
+	OwnerUUID  string
+	PageSize   uint64
+	SortBy     AdvertSortKey
+	Descending bool
+	Statuses   []AdvertStatus
+	Cursor     *AdvertsCursor
+}
+
+// AdvertsCursor - позиция последнего объявления предыдущей страницы для keyset-пагинации
+type AdvertsCursor struct {
+	SortBy     AdvertSortKey `json:"s"`
+	Descending bool          `json:"d"`
+	Value      string        `json:"v"`
+	ID         int64         `json:"id"`
+}
+
+type AdvertsPageResult struct {
+	Adverts    AdvertInfoList
+	NextCursor *AdvertsCursor
+	TotalCount int64
+}
+
+func (p *AdvertsPage) ToDTO(ownerUUID string, in *advert_api.GetAdvertsIn) error {
+	sortBy, ok := sortKeyFromProto[in.SortBy]
+	if !ok {
+		return fmt.Errorf("unknown sort key: %v", in.SortBy)
+	}
+
+	p.OwnerUUID = ownerUUID
+	p.SortBy = sortBy
+	p.Descending = in.Descending
+	p.PageSize = pageSize(in.PageSize)
+
+	p.Statuses = make([]AdvertStatus, 0, len(in.Statuses))
+	for _, st := range in.Statuses {
+		advertStatus, ok := StatusFromProto(st)
+		if !ok {
+			return fmt.Errorf("unknown status: %v", st)
+		}
+		p.Statuses = append(p.Statuses, advertStatus)
+	}
+
+	if in.PageToken == "" {
+		return nil
+	}
+
+	cursor, err := DecodeAdvertsCursor(in.PageToken)
+	if err != nil {
+		return err
+	}
+	if cursor.SortBy != p.SortBy || cursor.Descending != p.Descending {
+		return fmt.Errorf("%w: sort order differs from the previous page", ErrInvalidPageToken)
+	}
+	p.Cursor = cursor
+
+	return nil
+}
+
+// pageSize приводит запрошенный размер страницы к границам DefaultPageSize и MaxPageSize
+func pageSize(size int32) uint64 {
+	switch {
+	case size <= 0:
+		return DefaultPageSize
+	case size > MaxPageSize:
+		return MaxPageSize
+	default:
+		return uint64(size)
+	}
+}
+
+func (a *AdvertInfo) Cursor(sortBy AdvertSortKey, descending bool) *AdvertsCursor {
+	cursor := &AdvertsCursor{SortBy: sortBy, Descending: descending, ID: a.ID}
+
+	switch sortBy {
+	case SortByExpiredAt:
+		cursor.Value = a.ExpiredAt.Format(time.RFC3339Nano)
+	case SortByTitle:
+		cursor.Value = a.Title
+	default:
+		cursor.Value = a.CreatedAt.Format(time.RFC3339Nano)
+	}
+
+	return cursor
+}
+
+func (c *AdvertsCursor) Encode() string {
+	b, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func DecodeAdvertsCursor(token string) (*AdvertsCursor, error) {
+	b, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidPageToken, err)
+	}
+
+	var cursor AdvertsCursor
+	if err = json.Unmarshal(b, &cursor); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidPageToken, err)
+	}
+
+	return &cursor, nil
+}
+
+func (r *AdvertsPageResult) NextPageToken() string {
+	if r.NextCursor == nil {
+		return ""
+	}
+	return r.NextCursor.Encode()
+}
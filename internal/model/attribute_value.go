@@ -0,0 +1,43 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// AttributeOs - атрибут операционной системы пользователя, по которому фильтруются объявления
+const AttributeOs = "os"
+
+var ErrInvalidAttributeValue = errors.New("invalid attribute value")
+
+// AttributeValue - значение атрибута из топика STAFF_SET_ATTRIBUTE
+type AttributeValue struct {
+	Attribute string    `json:"attribute" db:"attribute"`
+	ValueID   int64     `json:"value_id" db:"value_id"`
+	Name      string    `json:"name" db:"name"`
+	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
+}
+
+func (a *AttributeValue) Validate() error {
+	switch {
+	case a.Attribute == "":
+		return fmt.Errorf("%w: attribute is empty", ErrInvalidAttributeValue)
+	case a.ValueID <= 0:
+		return fmt.Errorf("%w: value_id must be positive, got %d", ErrInvalidAttributeValue, a.ValueID)
+	case a.Name == "":
+		return fmt.Errorf("%w: name is empty", ErrInvalidAttributeValue)
+	}
+	return nil
+}
+
+// AttributeNames сопоставляет id значения атрибута с его названием
+type AttributeNames map[int64]string
+
+// DeadLetter - сообщение из топика атрибутов, которое не удалось обработать
+type DeadLetter struct {
+	ID       int64  `db:"id"`
+	Payload  []byte `db:"payload"`
+	Error    string `db:"error"`
+	Attempts int    `db:"attempts"`
+}
@@ -1,17 +1,68 @@
 package model
 
-import advert_api "github.com/s21platform/advert-service/pkg/advert"
+import (
+	"time"
+
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+
+	advert_api "github.com/s21platform/advert-service/pkg/advert"
+)
+
+// поля объявления, которые можно передать в update_mask
+const (
+	EditFieldTitle       = "title"
+	EditFieldTextContent = "text_content"
+	EditFieldUserFilter  = "user_filter"
+	EditFieldExpiredAt   = "expired_at"
+	EditFieldStartAt     = "start_at"
+)
+
+// defaultEditFields обновляются при пустой маске, как до появления update_mask
+var defaultEditFields = []string{EditFieldTitle, EditFieldTextContent, EditFieldUserFilter}
 
 type EditAdvert struct {
-	ID          int        `db:"id"`
+	ID          int64      `db:"id"`
 	Title       string     `db:"title"`
 	TextContent string     `db:"text_content"`
 	UserFilter  UserFilter `db:"filter"`
+	ExpiredAt   *time.Time `db:"expired_at"`
+	StartAt     *time.Time `db:"start_at"`
+	Fields      []string
+	EditedBy    string
 }
 
-func (e *EditAdvert) ToDTO(in *advert_api.EditAdvertIn) {
-	e.ID = int(in.Id)
-	e.Title = in.Title
-	e.TextContent = in.TextContent
-	e.UserFilter = UserFilter{Os: in.UserFilter.Os}
+func (e *EditAdvert) ToDTO(editedBy string, in *advert_api.EditAdvertIn) {
+	e.EditedBy = editedBy
+	e.ID = in.GetId()
+	e.Title = in.GetTitle()
+	e.TextContent = in.GetTextContent()
+	e.UserFilter = UserFilter{Os: in.GetUserFilter().GetOs()}
+	if in.GetExpiredAt() != nil {
+		expiredAt := in.GetExpiredAt().AsTime()
+		e.ExpiredAt = &expiredAt
+	}
+	if in.GetStartAt() != nil {
+		startAt := in.GetStartAt().AsTime()
+		e.StartAt = &startAt
+	}
+	e.Fields = EditFields(in.GetUpdateMask())
+}
+
+// EditFields возвращает поля из маски без повторов или поля по умолчанию для пустой маски
+func EditFields(mask *fieldmaskpb.FieldMask) []string {
+	paths := mask.GetPaths()
+	if len(paths) == 0 {
+		return defaultEditFields
+	}
+
+	fields := make([]string, 0, len(paths))
+	seen := make(map[string]struct{}, len(paths))
+	for _, path := range paths {
+		if _, ok := seen[path]; ok {
+			continue
+		}
+		seen[path] = struct{}{}
+		fields = append(fields, path)
+	}
+	return fields
 }
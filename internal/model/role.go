@@ -0,0 +1,25 @@
+package model
+
+import "errors"
+
+var ErrPermissionDenied = errors.New("permission denied")
+
+const (
+	RoleUser      = "user"
+	RoleModerator = "moderator"
+	RoleService   = "service"
+)
+
+// Caller - аутентифицированный вызывающий RPC
+type Caller struct {
+	UUID string
+	Role string
+}
+
+func IsKnownRole(role string) bool {
+	switch role {
+	case RoleUser, RoleModerator, RoleService:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,45 @@
+package model
+
+import (
+	"fmt"
+
+	advert_api "github.com/s21platform/advert-service/pkg/advert"
+)
+
+// UserAttributes - атрибуты пользователя для сверки с UserFilter. Фильтр объявлений и справочник
+// атрибутов знают только os, поэтому других атрибутов здесь нет
+type UserAttributes struct {
+	Os int64
+}
+
+// UserAdvertsPage - запрос страницы ленты пользователя, отсортированной по created_at от новых к старым
+type UserAdvertsPage struct {
+	Attrs    UserAttributes
+	PageSize uint64
+	Cursor   *AdvertsCursor
+}
+
+func (p *UserAdvertsPage) ToDTO(in *advert_api.GetAdvertsForUserIn) error {
+	p.Attrs.Os = in.GetUser().GetOs()
+	p.PageSize = pageSize(in.GetPageSize())
+
+	if in.GetPageToken() == "" {
+		return nil
+	}
+
+	cursor, err := DecodeAdvertsCursor(in.GetPageToken())
+	if err != nil {
+		return err
+	}
+	if cursor.SortBy != SortByCreatedAt || !cursor.Descending {
+		return fmt.Errorf("%w: token is not from the user feed", ErrInvalidPageToken)
+	}
+	p.Cursor = cursor
+
+	return nil
+}
+
+// OsFilter возвращает JSONB для проверки вхождения os пользователя в filter объявления
+func (u *UserAttributes) OsFilter() string {
+	return fmt.Sprintf(`{"os":[%d]}`, u.Os)
+}
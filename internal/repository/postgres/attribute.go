@@ -0,0 +1,151 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Masterminds/squirrel"
+
+	"github.com/s21platform/advert-service/internal/model"
+)
+
+// UpsertAttributeValue сохраняет значение атрибута; более старое сообщение не затирает новое
+func (r *Repository) UpsertAttributeValue(ctx context.Context, value model.AttributeValue) error {
+	ctx, done := r.observe(ctx, "UpsertAttributeValue")
+	defer done()
+
+	query, args, err := squirrel.
+		Insert("attribute_value").
+		Columns("attribute", "value_id", "name", "updated_at").
+		Values(value.Attribute, value.ValueID, value.Name, value.UpdatedAt).
+		Suffix(`ON CONFLICT (attribute, value_id) DO UPDATE
+SET name = EXCLUDED.name, updated_at = EXCLUDED.updated_at
+WHERE attribute_value.updated_at <= EXCLUDED.updated_at`).
+		PlaceholderFormat(squirrel.Dollar).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to build upsert attribute value query: %w", err)
+	}
+
+	_, err = r.connection.ExecContext(ctx, query, args...)
+	if err != nil {
+		return wrapDBError(err, "failed to upsert attribute value")
+	}
+
+	return nil
+}
+
+func (r *Repository) GetAttributeNames(ctx context.Context, attribute string, ids []int64) (model.AttributeNames, error) {
+	ctx, done := r.observe(ctx, "GetAttributeNames")
+	defer done()
+
+	names := make(model.AttributeNames, len(ids))
+	if len(ids) == 0 {
+		return names, nil
+	}
+
+	query, args, err := squirrel.
+		Select("value_id", "name").
+		From("attribute_value").
+		Where(squirrel.Eq{"attribute": attribute, "value_id": ids}).
+		PlaceholderFormat(squirrel.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build SQL query: %w", err)
+	}
+
+	var values []model.AttributeValue
+	err = r.connection.SelectContext(ctx, &values, query, args...)
+	if err != nil {
+		return nil, wrapDBError(err, "failed to get attribute values")
+	}
+
+	for _, value := range values {
+		names[value.ValueID] = value.Name
+	}
+
+	return names, nil
+}
+
+func (r *Repository) SaveDeadLetter(ctx context.Context, payload []byte, reason string, attempts int) error {
+	ctx, done := r.observe(ctx, "SaveDeadLetter")
+	defer done()
+
+	query, args, err := squirrel.
+		Insert("attribute_dlq").
+		Columns("payload", "error", "attempts").
+		Values(payload, reason, attempts).
+		PlaceholderFormat(squirrel.Dollar).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to build insert dead letter query: %w", err)
+	}
+
+	_, err = r.connection.ExecContext(ctx, query, args...)
+	if err != nil {
+		return wrapDBError(err, "failed to save dead letter")
+	}
+
+	return nil
+}
+
+// GetDeadLetters возвращает неповторённые сообщения с id больше afterID по возрастанию id
+func (r *Repository) GetDeadLetters(ctx context.Context, afterID int64, limit uint64) ([]model.DeadLetter, error) {
+	ctx, done := r.observe(ctx, "GetDeadLetters")
+	defer done()
+
+	query, args, err := squirrel.
+		Select("id", "payload", "error", "attempts").
+		From("attribute_dlq").
+		Where(squirrel.Eq{"replayed_at": nil}).
+		Where(squirrel.Gt{"id": afterID}).
+		OrderBy("id").
+		Limit(limit).
+		PlaceholderFormat(squirrel.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build SQL query: %w", err)
+	}
+
+	var letters []model.DeadLetter
+	err = r.connection.SelectContext(ctx, &letters, query, args...)
+	if err != nil {
+		return nil, wrapDBError(err, "failed to get dead letters")
+	}
+
+	return letters, nil
+}
+
+// ResolveDeadLetter отмечает сообщение повторённым или, если повтор не удался, сохраняет новую ошибку
+func (r *Repository) ResolveDeadLetter(ctx context.Context, ID int64, replayErr error) error {
+	ctx, done := r.observe(ctx, "ResolveDeadLetter")
+	defer done()
+
+	update := squirrel.
+		Update("attribute_dlq").
+		Where(squirrel.Eq{"id": ID}).
+		PlaceholderFormat(squirrel.Dollar)
+
+	if replayErr != nil {
+		update = update.
+			Set("error", replayErr.Error()).
+			Set("attempts", squirrel.Expr("attempts + 1"))
+	} else {
+		update = update.Set("replayed_at", squirrel.Expr("NOW()"))
+	}
+
+	query, args, err := update.ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to build update dead letter query: %w", err)
+	}
+
+	res, err := r.connection.ExecContext(ctx, query, args...)
+	if err != nil {
+		return wrapDBError(err, "failed to update dead letter")
+	}
+	if err = checkAffected(res); err != nil {
+		return fmt.Errorf("failed to update dead letter: %w", err)
+	}
+
+	return nil
+}
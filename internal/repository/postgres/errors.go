@@ -0,0 +1,58 @@
+package postgres
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/lib/pq"
+)
+
+var (
+	ErrNotFound           = errors.New("advert not found")
+	ErrConflict           = errors.New("advert conflict")
+	ErrPreconditionFailed = errors.New("advert precondition failed")
+	// ErrRetryable - транзакция проиграла конкурентной; запрос можно повторить
+	ErrRetryable = errors.New("advert concurrent update, retry")
+)
+
+// коды ошибок postgres, которые приводятся к ошибкам репозитория
+const (
+	pqCheckViolation       = "23514"
+	pqUniqueViolation      = "23505"
+	pqSerializationFailure = "40001"
+	pqLockNotAvailable     = "55P03"
+)
+
+// wrapDBError приводит ошибку драйвера к ошибкам репозитория, сохраняя исходную в цепочке
+func wrapDBError(err error, msg string) error {
+	if errors.Is(err, sql.ErrNoRows) {
+		return fmt.Errorf("%s: %w", msg, ErrNotFound)
+	}
+
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) {
+		switch pqErr.Code {
+		case pqUniqueViolation:
+			return fmt.Errorf("%s: %w: %w", msg, ErrConflict, err)
+		case pqSerializationFailure, pqLockNotAvailable:
+			return fmt.Errorf("%s: %w: %w", msg, ErrRetryable, err)
+		case pqCheckViolation:
+			return fmt.Errorf("%s: %w: %w", msg, ErrPreconditionFailed, err)
+		}
+	}
+
+	return fmt.Errorf("%s: %w", msg, err)
+}
+
+// checkAffected возвращает ErrNotFound, если запрос не изменил ни одной строки
+func checkAffected(res sql.Result) error {
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	if affected == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
@@ -0,0 +1,126 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+
+	"github.com/s21platform/advert-service/internal/model"
+)
+
+// insertOutbox записывает событие в outbox в транзакции изменения объявления
+func insertOutbox(ctx context.Context, tx *sqlx.Tx, event model.AdvertEvent) error {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to marshal advert event: %w", err)
+	}
+
+	query, args, err := squirrel.
+		Insert("advert_outbox").
+		Columns("advert_id", "event_type", "payload", "trace_context").
+		Values(event.AdvertID, event.Type, payload, model.NewTraceContext(ctx)).
+		PlaceholderFormat(squirrel.Dollar).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to build insert outbox query: %w", err)
+	}
+
+	_, err = tx.ExecContext(ctx, query, args...)
+	if err != nil {
+		return wrapDBError(err, "failed to save advert event")
+	}
+
+	return nil
+}
+
+// pendingOutboxQuery берёт только самое раннее неотправленное событие каждого объявления,
+// поэтому события одного объявления уходят по порядку даже при нескольких репликах
+const pendingOutboxQuery = `
+SELECT id, advert_id, event_type, payload, attempts, trace_context
+FROM advert_outbox o
+WHERE published_at IS NULL
+  AND next_attempt_at <= NOW()
+  AND NOT EXISTS (
+      SELECT 1 FROM advert_outbox prev
+      WHERE prev.advert_id = o.advert_id AND prev.published_at IS NULL AND prev.id < o.id
+  )
+ORDER BY id
+LIMIT $1
+FOR UPDATE SKIP LOCKED`
+
+// ProcessOutbox отправляет пачку событий; неудачные откладываются по backoff, успешные помечаются отправленными
+func (r *Repository) ProcessOutbox(ctx context.Context, limit uint64, publish model.PublishOutboxFunc, backoff model.BackoffFunc) (int, error) {
+	ctx, done := r.observe(ctx, "ProcessOutbox")
+	defer done()
+
+	tx, err := r.connection.BeginTxx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	var events []model.OutboxEvent
+	err = tx.SelectContext(ctx, &events, pendingOutboxQuery, limit)
+	if err != nil {
+		return 0, wrapDBError(err, "failed to select outbox events")
+	}
+
+	if len(events) == 0 {
+		return 0, nil
+	}
+
+	// вся пачка уходит одной записью в kafka, пока строки заблокированы
+	errs := publish(ctx, events)
+
+	var publishedIDs []int64
+	for i, event := range events {
+		if errs[i] == nil {
+			publishedIDs = append(publishedIDs, event.ID)
+			continue
+		}
+
+		query, args, err := squirrel.
+			Update("advert_outbox").
+			Set("attempts", event.Attempts+1).
+			Set("last_error", errs[i].Error()).
+			Set("next_attempt_at", time.Now().Add(backoff(event.Attempts+1))).
+			Where(squirrel.Eq{"id": event.ID}).
+			PlaceholderFormat(squirrel.Dollar).
+			ToSql()
+		if err != nil {
+			return 0, fmt.Errorf("failed to build update outbox query: %w", err)
+		}
+
+		_, err = tx.ExecContext(ctx, query, args...)
+		if err != nil {
+			return 0, wrapDBError(err, "failed to update outbox event")
+		}
+	}
+
+	if len(publishedIDs) > 0 {
+		query, args, err := squirrel.
+			Update("advert_outbox").
+			Set("published_at", time.Now()).
+			Where(squirrel.Eq{"id": publishedIDs}).
+			PlaceholderFormat(squirrel.Dollar).
+			ToSql()
+		if err != nil {
+			return 0, fmt.Errorf("failed to build update outbox query: %w", err)
+		}
+
+		_, err = tx.ExecContext(ctx, query, args...)
+		if err != nil {
+			return 0, wrapDBError(err, "failed to mark outbox events published")
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return 0, wrapDBError(err, "failed to commit transaction")
+	}
+
+	return len(publishedIDs), nil
+}
@@ -4,21 +4,30 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"slices"
 	"time"
 
 	"github.com/Masterminds/squirrel"
 	"github.com/jmoiron/sqlx"
+	"go.opentelemetry.io/otel"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+
+	pkg "github.com/s21platform/metrics-lib/pkg"
 
 	"github.com/s21platform/advert-service/internal/config"
 	"github.com/s21platform/advert-service/internal/model"
 	advert_api "github.com/s21platform/advert-service/pkg/advert"
 )
 
+var tracer = otel.Tracer("github.com/s21platform/advert-service/internal/repository/postgres")
+
 type Repository struct {
 	connection *sqlx.DB
+	metrics    pkg.MetricInterface
 }
 
-func New(cfg *config.Config) *Repository {
+func New(cfg *config.Config, metrics pkg.MetricInterface) *Repository {
 	conStr := fmt.Sprintf("user=%s password=%s dbname=%s host=%s port=%s sslmode=disable",
 		cfg.Postgres.User, cfg.Postgres.Password, cfg.Postgres.Database, cfg.Postgres.Host, cfg.Postgres.Port)
 
@@ -29,6 +38,7 @@ func New(cfg *config.Config) *Repository {
 
 	return &Repository{
 		connection: conn,
+		metrics:    metrics,
 	}
 }
 
@@ -36,200 +46,736 @@ func (r *Repository) Close() {
 	_ = r.connection.Close()
 }
 
+func (r *Repository) Ping(ctx context.Context) error {
+	return r.connection.PingContext(ctx)
+}
+
+// observe открывает span запроса к базе; done закрывает его и пишет длительность в метрики
+func (r *Repository) observe(ctx context.Context, query string) (context.Context, func()) {
+	start := time.Now()
+	ctx, span := tracer.Start(ctx, "postgres."+query,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(semconv.DBSystemPostgreSQL, semconv.DBOperationName(query)),
+	)
+
+	return ctx, func() {
+		span.End()
+		r.metrics.Duration(time.Since(start).Milliseconds(), "db."+query)
+	}
+}
+
 func (r *Repository) CreateAdvert(ctx context.Context, UUID string, in *advert_api.CreateAdvertIn) error {
+	ctx, done := r.observe(ctx, "CreateAdvert")
+	defer done()
+
 	var advertObj model.Advert
 
 	advertObj, err := advertObj.AdvertToDTO(UUID, in)
 	if err != nil {
-		return fmt.Errorf("failed toconvert grpc message to dto: %v", err)
+		return fmt.Errorf("failed toconvert grpc message to dto: %w", err)
+	}
+
+	tx, err := r.connection.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
 	}
+	defer func() { _ = tx.Rollback() }()
 
 	query := squirrel.Insert("advert_text").
-		Columns("owner_uuid", "title", "text_content", "filter", "expired_at").
-		Values(advertObj.OwnerUUID, advertObj.Title, advertObj.TextContent, advertObj.UserFilter, advertObj.ExpiresAt).
+		Columns("owner_uuid", "title", "text_content", "filter", "expired_at", "start_at", "status").
+		Values(advertObj.OwnerUUID, advertObj.Title, advertObj.TextContent, advertObj.UserFilter, advertObj.ExpiresAt, advertObj.StartAt, model.StatusActive).
+		Suffix("RETURNING id").
 		PlaceholderFormat(squirrel.Dollar)
 
 	sql, args, err := query.ToSql()
 
 	if err != nil {
-		return fmt.Errorf("failed to build SQL query: %v", err)
+		return fmt.Errorf("failed to build SQL query: %w", err)
 	}
 
-	_, err = r.connection.ExecContext(ctx, sql, args...)
+	var ID int64
+	err = tx.GetContext(ctx, &ID, sql, args...)
 	if err != nil {
-		return fmt.Errorf("failed to create advert: %v", err)
+		return wrapDBError(err, "failed to create advert")
+	}
+
+	if err = insertRevision(ctx, tx, ID, UUID); err != nil {
+		return err
+	}
+
+	err = insertOutbox(ctx, tx, model.AdvertEvent{
+		Type:       model.EventAdvertCreated,
+		AdvertID:   ID,
+		OwnerUUID:  UUID,
+		Version:    1,
+		Status:     model.StatusActive,
+		ExpiredAt:  &advertObj.ExpiresAt,
+		OccurredAt: time.Now(),
+	})
+	if err != nil {
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return wrapDBError(err, "failed to commit transaction")
 	}
 
 	return nil
 }
 
 func (r *Repository) GetAdvert(ctx context.Context, in *advert_api.GetAdvertIn) (*model.AdvertInfo, error) {
+	ctx, done := r.observe(ctx, "GetAdvert")
+	defer done()
+
 	var advert model.AdvertInfo
 
-	query, args, err := squirrel.Select("id", "title", "text_content", "expired_at").
+	query, args, err := squirrel.Select("id", "owner_uuid", "title", "text_content", "expired_at", statusColumn, "COALESCE(ban_reason, '') AS ban_reason", "version", "start_at").
 		From("advert_text").
 		Where(squirrel.Eq{"id": in.Id}).
 		PlaceholderFormat(squirrel.Dollar).
 		ToSql()
 	if err != nil {
-		return nil, fmt.Errorf("failed to build SQL query: %v", err)
+		return nil, fmt.Errorf("failed to build SQL query: %w", err)
 	}
 
-	err = r.connection.SelectContext(ctx, &advert, query, args...)
+	err = r.connection.GetContext(ctx, &advert, query, args...)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get advert from db: %v", err)
+		return nil, wrapDBError(err, "failed to get advert from db")
 	}
 
 	return &advert, nil
 }
 
-func (r *Repository) GetAdverts(UUID string) (*model.AdvertInfoList, error) {
-	var adverts model.AdvertInfoList
+func (r *Repository) GetAdverts(ctx context.Context, page *model.AdvertsPage) (*model.AdvertsPageResult, error) {
+	ctx, done := r.observe(ctx, "GetAdverts")
+	defer done()
 
-	query := squirrel.Select("id", "title", "text_content", "expired_at").
+	filter := squirrel.And{squirrel.Eq{"owner_uuid": page.OwnerUUID}}
+	if len(page.Statuses) > 0 {
+		filter = append(filter, squirrel.Eq{effectiveStatus: page.Statuses})
+	}
+
+	countQuery, countArgs, err := squirrel.Select("COUNT(*)").
 		From("advert_text").
-		Where(squirrel.Eq{"owner_uuid": UUID}).
+		Where(filter).
+		PlaceholderFormat(squirrel.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build count query: %w", err)
+	}
+
+	var total int64
+	err = r.connection.GetContext(ctx, &total, countQuery, countArgs...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count adverts: %w", err)
+	}
+
+	sortColumn, cast := sortExpr(page.SortBy)
+	direction, cmp := "ASC", ">"
+	if page.Descending {
+		direction, cmp = "DESC", "<"
+	}
+
+	query := squirrel.Select("id", "title", "text_content", "expired_at", "created_at", statusColumn, "COALESCE(ban_reason, '') AS ban_reason", "version", "start_at").
+		From("advert_text").
+		Where(filter).
+		OrderBy(fmt.Sprintf("%s %s", sortColumn, direction), fmt.Sprintf("id %s", direction)).
+		Limit(page.PageSize + 1).
 		PlaceholderFormat(squirrel.Dollar)
 
+	if page.Cursor != nil {
+		query = query.Where(squirrel.Expr(
+			fmt.Sprintf("(%s, id) %s (?%s, ?)", sortColumn, cmp, cast),
+			page.Cursor.Value, page.Cursor.ID,
+		))
+	}
+
 	sql, args, err := query.ToSql()
 	if err != nil {
-		return nil, fmt.Errorf("failed to build SQL query: %v", err)
+		return nil, fmt.Errorf("failed to build SQL query: %w", err)
 	}
 
-	err = r.connection.Select(&adverts, sql, args...)
+	var adverts model.AdvertInfoList
+	err = r.connection.SelectContext(ctx, &adverts, sql, args...)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get adverts from db: %v", err)
+		return nil, fmt.Errorf("failed to get adverts from db: %w", err)
+	}
+
+	result := &model.AdvertsPageResult{TotalCount: total}
+	if uint64(len(adverts)) > page.PageSize {
+		adverts = adverts[:page.PageSize]
+		result.NextCursor = adverts[len(adverts)-1].Cursor(page.SortBy, page.Descending)
 	}
+	result.Adverts = adverts
 
-	return &adverts, nil
+	return result, nil
 }
 
-func (r *Repository) CancelAdvert(ctx context.Context, in *advert_api.CancelAdvertIn) error {
-	updateQuery := squirrel.Update("advert_text").
-		Set("is_canceled", true).
-		Set("canceled_at", time.Now()).
-		Where(squirrel.And{
-			squirrel.Eq{"id": in.Id},
-			squirrel.Eq{"is_canceled": false},
-			squirrel.Gt{"expired_at": time.Now().Unix()},
+func (r *Repository) GetAdvertsForUser(ctx context.Context, page *model.UserAdvertsPage) (*model.AdvertsPageResult, error) {
+	ctx, done := r.observe(ctx, "GetAdvertsForUser")
+	defer done()
+
+	query := squirrel.Select("id", "title", "text_content", "expired_at", "created_at", statusColumn, "version", "start_at", "filter").
+		From("advert_text").
+		Where(activeAdvertCond()).
+		Where(squirrel.Or{
+			squirrel.Eq{"filter": nil},
+			squirrel.Expr("filter -> 'os' IS NULL"),
+			squirrel.Expr("filter @> ?::jsonb", page.Attrs.OsFilter()),
 		}).
+		OrderBy("created_at DESC", "id DESC").
+		Limit(page.PageSize + 1).
 		PlaceholderFormat(squirrel.Dollar)
 
-	sql, args, err := updateQuery.ToSql()
+	if page.Cursor != nil {
+		query = query.Where(squirrel.Expr("(created_at, id) < (?::timestamp, ?)", page.Cursor.Value, page.Cursor.ID))
+	}
+
+	sql, args, err := query.ToSql()
 	if err != nil {
-		return fmt.Errorf("failed to build update query: %v", err)
+		return nil, fmt.Errorf("failed to build SQL query: %w", err)
 	}
 
-	_, err = r.connection.ExecContext(ctx, sql, args...)
+	var adverts model.AdvertInfoList
+	err = r.connection.SelectContext(ctx, &adverts, sql, args...)
 	if err != nil {
-		return fmt.Errorf("failed to set cancel status in data: %v", err)
+		return nil, fmt.Errorf("failed to get adverts for user from db: %w", err)
 	}
 
-	return nil
+	result := &model.AdvertsPageResult{}
+	if uint64(len(adverts)) > page.PageSize {
+		adverts = adverts[:page.PageSize]
+		result.NextCursor = adverts[len(adverts)-1].Cursor(model.SortByCreatedAt, true)
+	}
+	result.Adverts = adverts
+
+	return result, nil
 }
 
-func (r *Repository) GetAdvertCancelExpiry(ctx context.Context, ID int64) (*model.AdvertCancelExpiry, error) {
-	var cancelExpiry model.AdvertCancelExpiry
+func (r *Repository) TransitAdvert(ctx context.Context, ID int64, fn model.TransitionFunc) error {
+	ctx, done := r.observe(ctx, "TransitAdvert")
+	defer done()
 
-	query := squirrel.
-		Select("is_canceled", "canceled_at", "expired_at").
-		From("advert_text").
+	tx, err := r.connection.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	advert, err := lockAdvert(ctx, tx, ID)
+	if err != nil {
+		return err
+	}
+
+	transition, err := fn(advert)
+	if err != nil {
+		return err
+	}
+
+	if !advert.Status.CanTransitionTo(transition.To) {
+		return fmt.Errorf("%w: %w: %s -> %s", ErrPreconditionFailed, model.ErrForbiddenTransition, advert.Status, transition.To)
+	}
+
+	now := time.Now()
+	update := squirrel.
+		Update("advert_text").
+		Set("status", transition.To).
+		Set("version", squirrel.Expr("version + 1")).
 		Where(squirrel.Eq{"id": ID}).
 		PlaceholderFormat(squirrel.Dollar)
 
-	sql, args, err := query.ToSql()
+	switch advert.Status {
+	case model.StatusCanceled:
+		update = update.Set("canceled_at", nil)
+	case model.StatusPaused:
+		update = update.Set("paused_at", nil)
+	case model.StatusBanned:
+		update = update.
+			Set("banned_at", nil).
+			Set("ban_reason", nil).
+			Set("banned_by", nil)
+	}
+
+	switch transition.To {
+	case model.StatusCanceled:
+		update = update.Set("canceled_at", now)
+	case model.StatusPaused:
+		update = update.Set("paused_at", now)
+	case model.StatusBanned:
+		update = update.
+			Set("banned_at", now).
+			Set("ban_reason", transition.BanReason).
+			Set("banned_by", transition.BannedBy)
+	}
+
+	if transition.ExpiredAt != nil {
+		update = update.Set("expired_at", *transition.ExpiredAt)
+	}
+
+	query, args, err := update.ToSql()
 	if err != nil {
-		return nil, fmt.Errorf("failed to build select query: %v", err)
+		return fmt.Errorf("failed to build update query: %w", err)
 	}
 
-	err = r.connection.GetContext(ctx, &cancelExpiry, sql, args...)
+	res, err := tx.ExecContext(ctx, query, args...)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get advert data: %v", err)
+		return wrapDBError(err, "failed to update advert status")
+	}
+	if err = checkAffected(res); err != nil {
+		return fmt.Errorf("failed to update advert status: %w", err)
+	}
+
+	expiredAt := advert.ExpiredAt
+	if transition.ExpiredAt != nil {
+		expiredAt = transition.ExpiredAt
+	}
+	err = insertOutbox(ctx, tx, model.AdvertEvent{
+		Type:           model.EventAdvertStatusChanged,
+		AdvertID:       ID,
+		OwnerUUID:      advert.OwnerUUID,
+		Version:        advert.Version + 1,
+		Status:         transition.To,
+		PreviousStatus: advert.Status,
+		ExpiredAt:      expiredAt,
+		OccurredAt:     now,
+	})
+	if err != nil {
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return wrapDBError(err, "failed to commit transaction")
 	}
 
-	return &cancelExpiry, nil
+	return nil
 }
 
-func (r *Repository) RestoreAdvert(ctx context.Context, ID int64, newExpiredAt time.Time) error {
-	query := squirrel.
+func (r *Repository) EditAdvert(ctx context.Context, info *model.EditAdvert, check model.CheckFunc) error {
+	ctx, done := r.observe(ctx, "EditAdvert")
+	defer done()
+
+	tx, err := r.connection.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	advert, err := lockAdvert(ctx, tx, info.ID)
+	if err != nil {
+		return err
+	}
+
+	if err = check(advert); err != nil {
+		return err
+	}
+
+	update := squirrel.
 		Update("advert_text").
-		Set("is_canceled", false).
-		Set("canceled_at", nil).
-		Set("expired_at", newExpiredAt).
+		Set("version", squirrel.Expr("version + 1")).
+		Where(squirrel.Eq{"id": info.ID}).
+		PlaceholderFormat(squirrel.Dollar)
+
+	for _, field := range info.Fields {
+		switch field {
+		case model.EditFieldTitle:
+			update = update.Set("title", info.Title)
+		case model.EditFieldTextContent:
+			update = update.Set("text_content", info.TextContent)
+		case model.EditFieldUserFilter:
+			update = update.Set("filter", info.UserFilter)
+		case model.EditFieldExpiredAt:
+			update = update.Set("expired_at", info.ExpiredAt)
+		case model.EditFieldStartAt:
+			update = update.Set("start_at", info.StartAt)
+		default:
+			return fmt.Errorf("unknown edit field: %s", field)
+		}
+	}
+
+	query, args, err := update.ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to build update query: %w", err)
+	}
+
+	res, err := tx.ExecContext(ctx, query, args...)
+	if err != nil {
+		return wrapDBError(err, "failed to update advert")
+	}
+	if err = checkAffected(res); err != nil {
+		return fmt.Errorf("failed to update advert: %w", err)
+	}
+
+	if err = insertRevision(ctx, tx, info.ID, info.EditedBy); err != nil {
+		return err
+	}
+
+	// изменение срока пишется в историю продлений так же, как ExtendAdvert
+	expiredAt := advert.ExpiredAt
+	if slices.Contains(info.Fields, model.EditFieldExpiredAt) {
+		expiredAt = info.ExpiredAt
+		if err = insertExtension(ctx, tx, info.ID, advert.ExpiredAt, *info.ExpiredAt, false, info.EditedBy); err != nil {
+			return err
+		}
+	}
+
+	err = insertOutbox(ctx, tx, model.AdvertEvent{
+		Type:       model.EventAdvertEdited,
+		AdvertID:   info.ID,
+		OwnerUUID:  advert.OwnerUUID,
+		Version:    advert.Version + 1,
+		Status:     advert.Status,
+		ExpiredAt:  expiredAt,
+		OccurredAt: time.Now(),
+	})
+	if err != nil {
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return wrapDBError(err, "failed to commit transaction")
+	}
+
+	return nil
+}
+
+func (r *Repository) ExtendAdvert(ctx context.Context, ID int64, extendedBy string, fn model.ExtendFunc) error {
+	ctx, done := r.observe(ctx, "ExtendAdvert")
+	defer done()
+
+	tx, err := r.connection.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	advert, err := lockAdvert(ctx, tx, ID)
+	if err != nil {
+		return err
+	}
+
+	extension, err := fn(advert)
+	if err != nil {
+		return err
+	}
+
+	update := squirrel.
+		Update("advert_text").
+		Set("expired_at", extension.ExpiredAt).
+		Set("version", squirrel.Expr("version + 1")).
 		Where(squirrel.Eq{"id": ID}).
 		PlaceholderFormat(squirrel.Dollar)
 
-	sql, args, err := query.ToSql()
+	if extension.Renew {
+		if !advert.Status.CanTransitionTo(model.StatusActive) {
+			return fmt.Errorf("%w: %w: %s -> %s", ErrPreconditionFailed, model.ErrForbiddenTransition, advert.Status, model.StatusActive)
+		}
+		update = update.Set("status", model.StatusActive)
+	}
+
+	query, args, err := update.ToSql()
 	if err != nil {
-		return fmt.Errorf("failed to build update query: %v", err)
+		return fmt.Errorf("failed to build update query: %w", err)
 	}
 
-	_, err = r.connection.ExecContext(ctx, sql, args...)
+	res, err := tx.ExecContext(ctx, query, args...)
 	if err != nil {
-		return fmt.Errorf("failed to update advert: %v", err)
+		return wrapDBError(err, "failed to extend advert")
+	}
+	if err = checkAffected(res); err != nil {
+		return fmt.Errorf("failed to extend advert: %w", err)
+	}
+
+	if err = insertExtension(ctx, tx, ID, advert.ExpiredAt, extension.ExpiredAt, extension.Renew, extendedBy); err != nil {
+		return err
+	}
+
+	status := advert.Status
+	if extension.Renew {
+		status = model.StatusActive
+	}
+	err = insertOutbox(ctx, tx, model.AdvertEvent{
+		Type:           model.EventAdvertExtended,
+		AdvertID:       ID,
+		OwnerUUID:      advert.OwnerUUID,
+		Version:        advert.Version + 1,
+		Status:         status,
+		PreviousStatus: advert.Status,
+		ExpiredAt:      &extension.ExpiredAt,
+		OccurredAt:     time.Now(),
+	})
+	if err != nil {
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return wrapDBError(err, "failed to commit transaction")
 	}
 
 	return nil
 }
 
-func (r *Repository) IsAdvertActive(ctx context.Context, ID int) (bool, error) {
-	query, args, err := squirrel.
-		Select("is_canceled", "is_banned", "expired_at").
+// ExpireAdverts переводит пачку истёкших объявлений в expired; строки, захваченные другими репликами, пропускаются
+func (r *Repository) ExpireAdverts(ctx context.Context, limit uint64, publish model.PublishExpiredFunc) (int, error) {
+	ctx, done := r.observe(ctx, "ExpireAdverts")
+	defer done()
+
+	tx, err := r.connection.BeginTxx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	batch := squirrel.
+		Select("id").
 		From("advert_text").
-		Where(squirrel.Eq{"id": ID}).
+		Where(squirrel.Eq{"status": model.StatusActive}).
+		Where(squirrel.Expr("expired_at <= NOW()")).
+		OrderBy("expired_at").
+		Limit(limit).
+		Suffix("FOR UPDATE SKIP LOCKED")
+
+	query, args, err := squirrel.
+		Update("advert_text").
+		Set("status", model.StatusExpired).
+		Set("version", squirrel.Expr("version + 1")).
+		Where(batch.Prefix("id IN (").Suffix(")")).
+		Suffix("RETURNING id, owner_uuid, expired_at").
 		PlaceholderFormat(squirrel.Dollar).
 		ToSql()
 	if err != nil {
-		return false, fmt.Errorf("failed to build select query: %v", err)
+		return 0, fmt.Errorf("failed to build expire query: %w", err)
 	}
 
-	result := model.AdvertState{}
-	err = r.connection.GetContext(ctx, &result, query, args...)
+	var expired []model.AdvertExpired
+	err = tx.SelectContext(ctx, &expired, query, args...)
 	if err != nil {
-		return false, fmt.Errorf("failed to query advert: %v", err)
+		return 0, wrapDBError(err, "failed to expire adverts")
+	}
+
+	if len(expired) == 0 {
+		return 0, nil
+	}
+
+	if err = publish(ctx, expired); err != nil {
+		return 0, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return 0, wrapDBError(err, "failed to commit transaction")
 	}
 
-	isExpired := result.ExpiredAt.Valid && result.ExpiredAt.Time.Before(time.Now())
-	return !result.IsCanceled && !result.IsBanned && !isExpired, nil
+	return len(expired), nil
 }
 
-func (r *Repository) GetOwnerUUID(ctx context.Context, ID int) (string, error) {
+// remindExpiringQuery отмечает напоминание до выборки, поэтому при гонке реплик его отправит только одна.
+// Объявление попадает только в окно между leadTime ($1) и следующим меньшим порогом ($2): если уже
+// действует меньший порог, напоминание за больший не отправляется
+const remindExpiringQuery = `
+WITH due AS (
+    SELECT id, owner_uuid, expired_at
+    FROM advert_text a
+    WHERE status = 'active'
+      AND (start_at IS NULL OR start_at <= NOW())
+      AND expired_at > NOW() + make_interval(secs => $2::bigint)
+      AND expired_at <= NOW() + make_interval(secs => $1::bigint)
+      AND NOT EXISTS (
+          SELECT 1 FROM advert_expiry_reminder r
+          WHERE r.advert_id = a.id AND r.lead_seconds = $1::bigint AND r.expired_at = a.expired_at
+      )
+    ORDER BY expired_at
+    LIMIT $3
+), sent AS (
+    INSERT INTO advert_expiry_reminder (advert_id, lead_seconds, expired_at)
+    SELECT id, $1::bigint, expired_at FROM due
+    ON CONFLICT DO NOTHING
+    RETURNING advert_id
+)
+SELECT due.id, due.owner_uuid, due.expired_at,
+       FLOOR(EXTRACT(EPOCH FROM due.expired_at - NOW()) / 3600)::bigint AS hours_left
+FROM due
+JOIN sent ON sent.advert_id = due.id`
+
+// RemindExpiring выбирает объявления, которым ещё не отправлено напоминание за leadTime до текущего срока.
+// nextLeadTime - следующий меньший порог или 0, если leadTime наименьший
+func (r *Repository) RemindExpiring(ctx context.Context, leadTime, nextLeadTime time.Duration, limit uint64, publish model.PublishRemindersFunc) (int, error) {
+	ctx, done := r.observe(ctx, "RemindExpiring")
+	defer done()
+
+	tx, err := r.connection.BeginTxx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	var reminders []model.AdvertExpiryReminder
+	err = tx.SelectContext(ctx, &reminders, remindExpiringQuery, int64(leadTime.Seconds()), int64(nextLeadTime.Seconds()), limit)
+	if err != nil {
+		return 0, wrapDBError(err, "failed to select expiring adverts")
+	}
+
+	if len(reminders) == 0 {
+		return 0, nil
+	}
+
+	if err = publish(ctx, reminders); err != nil {
+		return 0, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return 0, wrapDBError(err, "failed to commit transaction")
+	}
+
+	return len(reminders), nil
+}
+
+func (r *Repository) GetAdvertRevisions(ctx context.Context, advertID int64) (*model.AdvertRevisionList, error) {
+	ctx, done := r.observe(ctx, "GetAdvertRevisions")
+	defer done()
+
+	query, args, err := squirrel.Select(revisionColumns...).
+		From("advert_revision").
+		Where(squirrel.Eq{"advert_id": advertID}).
+		OrderBy("version DESC").
+		PlaceholderFormat(squirrel.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build SQL query: %w", err)
+	}
+
+	var revisions model.AdvertRevisionList
+	err = r.connection.SelectContext(ctx, &revisions, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get advert revisions from db: %w", err)
+	}
+
+	return &revisions, nil
+}
+
+func (r *Repository) GetAdvertRevision(ctx context.Context, advertID, revisionID int64) (*model.AdvertRevision, error) {
+	ctx, done := r.observe(ctx, "GetAdvertRevision")
+	defer done()
+
+	query, args, err := squirrel.Select(revisionColumns...).
+		From("advert_revision").
+		Where(squirrel.Eq{"id": revisionID, "advert_id": advertID}).
+		PlaceholderFormat(squirrel.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build SQL query: %w", err)
+	}
+
+	var revision model.AdvertRevision
+	err = r.connection.GetContext(ctx, &revision, query, args...)
+	if err != nil {
+		return nil, wrapDBError(err, "failed to get advert revision from db")
+	}
+
+	return &revision, nil
+}
+
+var revisionColumns = []string{
+	"id", "advert_id", "version", "title", "COALESCE(text_content, '') AS text_content",
+	"filter", "expired_at", "edited_by", "created_at",
+}
+
+// insertRevision сохраняет текущее содержимое объявления как ревизию в той же транзакции
+func insertRevision(ctx context.Context, tx *sqlx.Tx, advertID int64, editedBy string) error {
 	query, args, err := squirrel.
-		Select("owner_uuid").
-		From("advert_text").
-		Where(squirrel.Eq{"id": ID}).
+		Insert("advert_revision").
+		Columns("advert_id", "version", "title", "text_content", "filter", "expired_at", "edited_by").
+		Select(squirrel.
+			Select("id", "version", "title", "text_content", "filter", "expired_at").
+			Column("?::uuid", editedBy).
+			From("advert_text").
+			Where(squirrel.Eq{"id": advertID})).
 		PlaceholderFormat(squirrel.Dollar).
 		ToSql()
 	if err != nil {
-		return "", fmt.Errorf("failed to build select query: %v", err)
+		return fmt.Errorf("failed to build insert revision query: %w", err)
 	}
 
-	var ownerUUID string
-	err = r.connection.GetContext(ctx, &ownerUUID, query, args...)
+	_, err = tx.ExecContext(ctx, query, args...)
 	if err != nil {
-		return "", fmt.Errorf("failed to query owner uuid: %v", err)
+		return wrapDBError(err, "failed to save advert revision")
 	}
 
-	return ownerUUID, nil
+	return nil
 }
 
-func (r *Repository) EditAdvert(ctx context.Context, info *model.EditAdvert) error {
+// insertExtension записывает изменение срока объявления в историю продлений в той же транзакции
+func insertExtension(ctx context.Context, tx *sqlx.Tx, advertID int64, previous *time.Time, next time.Time, renewed bool, extendedBy string) error {
 	query, args, err := squirrel.
-		Update("advert_text").
-		Set("text_content", info.TextContent).
-		Set("title", info.Title).
-		Set("filter", info.UserFilter).
-		Where(squirrel.Eq{"id": info.ID}).
+		Insert("advert_extension").
+		Columns("advert_id", "previous_expired_at", "new_expired_at", "renewed", "extended_by").
+		Values(advertID, previous, next, renewed, extendedBy).
 		PlaceholderFormat(squirrel.Dollar).
 		ToSql()
 	if err != nil {
-		return fmt.Errorf("failed to build update query: %v", err)
+		return fmt.Errorf("failed to build insert extension query: %w", err)
 	}
 
-	_, err = r.connection.ExecContext(ctx, query, args...)
+	_, err = tx.ExecContext(ctx, query, args...)
 	if err != nil {
-		return fmt.Errorf("failed to update advert: %v", err)
+		return wrapDBError(err, "failed to save advert extension")
 	}
 
 	return nil
 }
+
+// lockAdvert читает состояние объявления и блокирует строку до конца транзакции
+func lockAdvert(ctx context.Context, tx *sqlx.Tx, ID int64) (*model.AdvertLifecycle, error) {
+	query, args, err := squirrel.
+		Select("id", "owner_uuid", "status", "expired_at", "start_at", "canceled_at", "paused_at", "created_at", "version").
+		From("advert_text").
+		Where(squirrel.Eq{"id": ID}).
+		Suffix("FOR UPDATE").
+		PlaceholderFormat(squirrel.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build select query: %w", err)
+	}
+
+	var advert model.AdvertLifecycle
+	err = tx.GetContext(ctx, &advert, query, args...)
+	if err != nil {
+		return nil, wrapDBError(err, "failed to lock advert")
+	}
+
+	advert.Status = advert.CurrentStatus(time.Now())
+
+	return &advert, nil
+}
+
+// effectiveStatus возвращает статус с учётом истечения срока у ещё не переведённых в expired объявлений
+// и ещё не начавшихся объявлений
+const effectiveStatus = "CASE WHEN status = 'active' AND expired_at <= NOW() THEN 'expired' " +
+	"WHEN status = 'active' AND start_at > NOW() THEN 'scheduled' ELSE status END"
+
+const statusColumn = effectiveStatus + " AS status"
+
+// sortExpr возвращает колонку сортировки и приведение типа значения курсора
+func sortExpr(sortBy model.AdvertSortKey) (string, string) {
+	switch sortBy {
+	case model.SortByExpiredAt:
+		return "COALESCE(expired_at, TIMESTAMP 'epoch')", "::timestamp"
+	case model.SortByTitle:
+		return "title", ""
+	default:
+		return "created_at", "::timestamp"
+	}
+}
+
+// activeAdvertCond описывает объявление, которое видят пользователи: активно, уже началось и не истекло
+func activeAdvertCond() squirrel.Sqlizer {
+	return squirrel.And{
+		squirrel.Eq{"status": model.StatusActive},
+		squirrel.Or{
+			squirrel.Eq{"start_at": nil},
+			squirrel.Expr("start_at <= NOW()"),
+		},
+		squirrel.Or{
+			squirrel.Eq{"expired_at": nil},
+			squirrel.Expr("expired_at > NOW()"),
+		},
+	}
+}
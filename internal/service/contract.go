@@ -3,7 +3,6 @@ package service
 
 import (
 	"context"
-	"time"
 
 	"github.com/s21platform/advert-service/internal/model"
 	advert_api "github.com/s21platform/advert-service/pkg/advert"
@@ -12,11 +11,12 @@ import (
 type DBRepo interface {
 	CreateAdvert(ctx context.Context, UUID string, in *advert_api.CreateAdvertIn) error
 	GetAdvert(ctx context.Context, in *advert_api.GetAdvertIn) (*model.AdvertInfo, error)
-	GetAdverts(UUID string) (*model.AdvertInfoList, error)
-	CancelAdvert(ctx context.Context, in *advert_api.CancelAdvertIn) error
-	GetAdvertCancelExpiry(ctx context.Context, ID int64) (*model.AdvertCancelExpiry, error)
-	RestoreAdvert(ctx context.Context, ID int64, newExpiredAt time.Time) error
-	IsAdvertActive(ctx context.Context, ID int) (bool, error)
-	GetOwnerUUID(ctx context.Context, ID int) (string, error)
-	EditAdvert(ctx context.Context, info *model.EditAdvert) error
+	GetAdverts(ctx context.Context, page *model.AdvertsPage) (*model.AdvertsPageResult, error)
+	GetAdvertsForUser(ctx context.Context, page *model.UserAdvertsPage) (*model.AdvertsPageResult, error)
+	TransitAdvert(ctx context.Context, ID int64, fn model.TransitionFunc) error
+	EditAdvert(ctx context.Context, info *model.EditAdvert, check model.CheckFunc) error
+	ExtendAdvert(ctx context.Context, ID int64, extendedBy string, fn model.ExtendFunc) error
+	GetAdvertRevisions(ctx context.Context, advertID int64) (*model.AdvertRevisionList, error)
+	GetAdvertRevision(ctx context.Context, advertID, revisionID int64) (*model.AdvertRevision, error)
+	GetAttributeNames(ctx context.Context, attribute string, ids []int64) (model.AttributeNames, error)
 }
@@ -0,0 +1,96 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
+
+	"github.com/s21platform/advert-service/internal/model"
+	"github.com/s21platform/advert-service/internal/repository/postgres"
+)
+
+const (
+	errorDomain        = "advert-service"
+	advertResourceType = "advert"
+)
+
+// errorCode сопоставляет ошибки репозитория и модели кодам gRPC, всё неизвестное считается внутренней ошибкой
+func errorCode(err error) codes.Code {
+	var validationErr *ValidationError
+	switch {
+	case errors.As(err, &validationErr):
+		return codes.InvalidArgument
+	case errors.Is(err, model.ErrPermissionDenied):
+		return codes.PermissionDenied
+	case errors.Is(err, postgres.ErrNotFound):
+		return codes.NotFound
+	case errors.Is(err, postgres.ErrConflict):
+		return codes.AlreadyExists
+	case errors.Is(err, model.ErrVersionMismatch), errors.Is(err, postgres.ErrRetryable):
+		return codes.Aborted
+	case errors.Is(err, postgres.ErrPreconditionFailed),
+		errors.Is(err, model.ErrForbiddenTransition),
+		errors.Is(err, model.ErrAdvertNotActive),
+		errors.Is(err, model.ErrAdvertNotCanceled),
+		errors.Is(err, model.ErrAdvertNotBanned),
+		errors.Is(err, model.ErrAdvertNotPaused),
+		errors.Is(err, model.ErrRestoreWindowClosed),
+		errors.Is(err, model.ErrAdvertAlreadyLive),
+		errors.Is(err, model.ErrAdvertNotExtendable),
+		errors.Is(err, model.ErrRenewalWindowClosed),
+		errors.Is(err, model.ErrExpiryNotLater),
+		errors.Is(err, model.ErrMaxLifetimeExceeded):
+		return codes.FailedPrecondition
+	}
+	return codes.Internal
+}
+
+// statusError собирает gRPC-статус из ошибки и прикладывает детали, по которым клиент может её разобрать
+func statusError(err error, msg string) error {
+	code := errorCode(err)
+	st := status.New(code, fmt.Sprintf("%s: %v", msg, err))
+
+	var detail protoadapt.MessageV1
+	switch code {
+	case codes.InvalidArgument:
+		var validationErr *ValidationError
+		if errors.As(err, &validationErr) {
+			detail = &errdetails.BadRequest{FieldViolations: validationErr.Violations}
+		}
+	case codes.NotFound:
+		detail = &errdetails.ResourceInfo{
+			ResourceType: advertResourceType,
+			Description:  err.Error(),
+		}
+	case codes.FailedPrecondition:
+		detail = &errdetails.PreconditionFailure{
+			Violations: []*errdetails.PreconditionFailure_Violation{{
+				Type:        "STATUS",
+				Subject:     advertResourceType,
+				Description: err.Error(),
+			}},
+		}
+	case codes.AlreadyExists:
+		detail = &errdetails.ErrorInfo{Reason: "CONFLICT", Domain: errorDomain}
+	case codes.Aborted:
+		reason := "VERSION_MISMATCH"
+		if errors.Is(err, postgres.ErrRetryable) {
+			reason = "CONCURRENT_UPDATE"
+		}
+		detail = &errdetails.ErrorInfo{Reason: reason, Domain: errorDomain}
+	case codes.PermissionDenied:
+		detail = &errdetails.ErrorInfo{Reason: "PERMISSION_DENIED", Domain: errorDomain}
+	}
+
+	if detail != nil {
+		if withDetails, detailErr := st.WithDetails(detail); detailErr == nil {
+			st = withDetails
+		}
+	}
+
+	return st.Err()
+}
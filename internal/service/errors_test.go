@@ -0,0 +1,101 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/s21platform/advert-service/internal/model"
+	"github.com/s21platform/advert-service/internal/repository/postgres"
+)
+
+func TestStatusError(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		err        error
+		wantCode   codes.Code
+		wantDetail any
+	}{
+		{
+			name:       "not_found",
+			err:        fmt.Errorf("failed to lock advert: %w", postgres.ErrNotFound),
+			wantCode:   codes.NotFound,
+			wantDetail: &errdetails.ResourceInfo{},
+		},
+		{
+			name:       "conflict",
+			err:        fmt.Errorf("failed to create advert: %w", postgres.ErrConflict),
+			wantCode:   codes.AlreadyExists,
+			wantDetail: &errdetails.ErrorInfo{},
+		},
+		{
+			name:       "retryable",
+			err:        fmt.Errorf("failed to lock advert: %w", postgres.ErrRetryable),
+			wantCode:   codes.Aborted,
+			wantDetail: &errdetails.ErrorInfo{Reason: "CONCURRENT_UPDATE", Domain: errorDomain},
+		},
+		{
+			name:       "forbidden_transition",
+			err:        fmt.Errorf("%w: %w", postgres.ErrPreconditionFailed, model.ErrForbiddenTransition),
+			wantCode:   codes.FailedPrecondition,
+			wantDetail: &errdetails.PreconditionFailure{},
+		},
+		{
+			name:       "version_mismatch",
+			err:        fmt.Errorf("%w: expected 1, actual 2", model.ErrVersionMismatch),
+			wantCode:   codes.Aborted,
+			wantDetail: &errdetails.ErrorInfo{Reason: "VERSION_MISMATCH", Domain: errorDomain},
+		},
+		{
+			name:       "not_active",
+			err:        model.ErrAdvertNotActive,
+			wantCode:   codes.FailedPrecondition,
+			wantDetail: &errdetails.PreconditionFailure{},
+		},
+		{
+			name:       "permission_denied",
+			err:        fmt.Errorf("%w: not owner", model.ErrPermissionDenied),
+			wantCode:   codes.PermissionDenied,
+			wantDetail: &errdetails.ErrorInfo{},
+		},
+		{
+			name: "invalid_argument",
+			err: &ValidationError{Violations: []*errdetails.BadRequest_FieldViolation{
+				{Field: "title", Description: "must not be empty"},
+			}},
+			wantCode:   codes.InvalidArgument,
+			wantDetail: &errdetails.BadRequest{},
+		},
+		{
+			name:     "internal",
+			err:      errors.New("connection refused"),
+			wantCode: codes.Internal,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			st, ok := status.FromError(statusError(tt.err, "failed"))
+			assert.True(t, ok)
+			assert.Equal(t, tt.wantCode, st.Code())
+			assert.Equal(t, fmt.Sprintf("failed: %v", tt.err), st.Message())
+
+			if tt.wantDetail == nil {
+				assert.Empty(t, st.Details())
+				return
+			}
+			assert.Len(t, st.Details(), 1)
+			assert.IsType(t, tt.wantDetail, st.Details()[0])
+			if info, ok := tt.wantDetail.(*errdetails.ErrorInfo); ok && info.Reason != "" {
+				assert.Equal(t, info.Reason, st.Details()[0].(*errdetails.ErrorInfo).GetReason())
+			}
+		})
+	}
+}
@@ -7,7 +7,6 @@ package service
 import (
 	context "context"
 	reflect "reflect"
-	time "time"
 
 	gomock "github.com/golang/mock/gomock"
 	model "github.com/s21platform/advert-service/internal/model"
@@ -37,20 +36,6 @@ func (m *MockDBRepo) EXPECT() *MockDBRepoMockRecorder {
 	return m.recorder
 }
 
-// CancelAdvert mocks base method.
-func (m *MockDBRepo) CancelAdvert(ctx context.Context, in *advert.CancelAdvertIn) error {
-	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CancelAdvert", ctx, in)
-	ret0, _ := ret[0].(error)
-	return ret0
-}
-
-// CancelAdvert indicates an expected call of CancelAdvert.
-func (mr *MockDBRepoMockRecorder) CancelAdvert(ctx, in interface{}) *gomock.Call {
-	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelAdvert", reflect.TypeOf((*MockDBRepo)(nil).CancelAdvert), ctx, in)
-}
-
 // CreateAdvert mocks base method.
 func (m *MockDBRepo) CreateAdvert(ctx context.Context, UUID string, in *advert.CreateAdvertIn) error {
 	m.ctrl.T.Helper()
@@ -66,17 +51,31 @@ func (mr *MockDBRepoMockRecorder) CreateAdvert(ctx, UUID, in interface{}) *gomoc
 }
 
 // EditAdvert mocks base method.
-func (m *MockDBRepo) EditAdvert(ctx context.Context, info *model.EditAdvert) error {
+func (m *MockDBRepo) EditAdvert(ctx context.Context, info *model.EditAdvert, check model.CheckFunc) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "EditAdvert", ctx, info)
+	ret := m.ctrl.Call(m, "EditAdvert", ctx, info, check)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // EditAdvert indicates an expected call of EditAdvert.
-func (mr *MockDBRepoMockRecorder) EditAdvert(ctx, info interface{}) *gomock.Call {
+func (mr *MockDBRepoMockRecorder) EditAdvert(ctx, info, check interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EditAdvert", reflect.TypeOf((*MockDBRepo)(nil).EditAdvert), ctx, info, check)
+}
+
+// ExtendAdvert mocks base method.
+func (m *MockDBRepo) ExtendAdvert(ctx context.Context, ID int64, extendedBy string, fn model.ExtendFunc) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExtendAdvert", ctx, ID, extendedBy, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ExtendAdvert indicates an expected call of ExtendAdvert.
+func (mr *MockDBRepoMockRecorder) ExtendAdvert(ctx, ID, extendedBy, fn interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EditAdvert", reflect.TypeOf((*MockDBRepo)(nil).EditAdvert), ctx, info)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExtendAdvert", reflect.TypeOf((*MockDBRepo)(nil).ExtendAdvert), ctx, ID, extendedBy, fn)
 }
 
 // GetAdvert mocks base method.
@@ -94,76 +93,91 @@ func (mr *MockDBRepoMockRecorder) GetAdvert(ctx, in interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAdvert", reflect.TypeOf((*MockDBRepo)(nil).GetAdvert), ctx, in)
 }
 
-// GetAdvertCancelExpiry mocks base method.
-func (m *MockDBRepo) GetAdvertCancelExpiry(ctx context.Context, ID int64) (*model.AdvertCancelExpiry, error) {
+// GetAdvertRevision mocks base method.
+func (m *MockDBRepo) GetAdvertRevision(ctx context.Context, advertID, revisionID int64) (*model.AdvertRevision, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAdvertRevision", ctx, advertID, revisionID)
+	ret0, _ := ret[0].(*model.AdvertRevision)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAdvertRevision indicates an expected call of GetAdvertRevision.
+func (mr *MockDBRepoMockRecorder) GetAdvertRevision(ctx, advertID, revisionID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAdvertRevision", reflect.TypeOf((*MockDBRepo)(nil).GetAdvertRevision), ctx, advertID, revisionID)
+}
+
+// GetAdvertRevisions mocks base method.
+func (m *MockDBRepo) GetAdvertRevisions(ctx context.Context, advertID int64) (*model.AdvertRevisionList, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAdvertCancelExpiry", ctx, ID)
-	ret0, _ := ret[0].(*model.AdvertCancelExpiry)
+	ret := m.ctrl.Call(m, "GetAdvertRevisions", ctx, advertID)
+	ret0, _ := ret[0].(*model.AdvertRevisionList)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetAdvertCancelExpiry indicates an expected call of GetAdvertCancelExpiry.
-func (mr *MockDBRepoMockRecorder) GetAdvertCancelExpiry(ctx, ID interface{}) *gomock.Call {
+// GetAdvertRevisions indicates an expected call of GetAdvertRevisions.
+func (mr *MockDBRepoMockRecorder) GetAdvertRevisions(ctx, advertID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAdvertCancelExpiry", reflect.TypeOf((*MockDBRepo)(nil).GetAdvertCancelExpiry), ctx, ID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAdvertRevisions", reflect.TypeOf((*MockDBRepo)(nil).GetAdvertRevisions), ctx, advertID)
 }
 
 // GetAdverts mocks base method.
-func (m *MockDBRepo) GetAdverts(UUID string) (*model.AdvertInfoList, error) {
+func (m *MockDBRepo) GetAdverts(ctx context.Context, page *model.AdvertsPage) (*model.AdvertsPageResult, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAdverts", UUID)
-	ret0, _ := ret[0].(*model.AdvertInfoList)
+	ret := m.ctrl.Call(m, "GetAdverts", ctx, page)
+	ret0, _ := ret[0].(*model.AdvertsPageResult)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetAdverts indicates an expected call of GetAdverts.
-func (mr *MockDBRepoMockRecorder) GetAdverts(UUID interface{}) *gomock.Call {
+func (mr *MockDBRepoMockRecorder) GetAdverts(ctx, page interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAdverts", reflect.TypeOf((*MockDBRepo)(nil).GetAdverts), UUID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAdverts", reflect.TypeOf((*MockDBRepo)(nil).GetAdverts), ctx, page)
 }
 
-// GetOwnerUUID mocks base method.
-func (m *MockDBRepo) GetOwnerUUID(ctx context.Context, ID int) (string, error) {
+// GetAdvertsForUser mocks base method.
+func (m *MockDBRepo) GetAdvertsForUser(ctx context.Context, page *model.UserAdvertsPage) (*model.AdvertsPageResult, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetOwnerUUID", ctx, ID)
-	ret0, _ := ret[0].(string)
+	ret := m.ctrl.Call(m, "GetAdvertsForUser", ctx, page)
+	ret0, _ := ret[0].(*model.AdvertsPageResult)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetOwnerUUID indicates an expected call of GetOwnerUUID.
-func (mr *MockDBRepoMockRecorder) GetOwnerUUID(ctx, ID interface{}) *gomock.Call {
+// GetAdvertsForUser indicates an expected call of GetAdvertsForUser.
+func (mr *MockDBRepoMockRecorder) GetAdvertsForUser(ctx, page interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOwnerUUID", reflect.TypeOf((*MockDBRepo)(nil).GetOwnerUUID), ctx, ID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAdvertsForUser", reflect.TypeOf((*MockDBRepo)(nil).GetAdvertsForUser), ctx, page)
 }
 
-// IsAdvertActive mocks base method.
-func (m *MockDBRepo) IsAdvertActive(ctx context.Context, ID int) (bool, error) {
+// GetAttributeNames mocks base method.
+func (m *MockDBRepo) GetAttributeNames(ctx context.Context, attribute string, ids []int64) (model.AttributeNames, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "IsAdvertActive", ctx, ID)
-	ret0, _ := ret[0].(bool)
+	ret := m.ctrl.Call(m, "GetAttributeNames", ctx, attribute, ids)
+	ret0, _ := ret[0].(model.AttributeNames)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// IsAdvertActive indicates an expected call of IsAdvertActive.
-func (mr *MockDBRepoMockRecorder) IsAdvertActive(ctx, ID interface{}) *gomock.Call {
+// GetAttributeNames indicates an expected call of GetAttributeNames.
+func (mr *MockDBRepoMockRecorder) GetAttributeNames(ctx, attribute, ids interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsAdvertActive", reflect.TypeOf((*MockDBRepo)(nil).IsAdvertActive), ctx, ID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAttributeNames", reflect.TypeOf((*MockDBRepo)(nil).GetAttributeNames), ctx, attribute, ids)
 }
 
-// RestoreAdvert mocks base method.
-func (m *MockDBRepo) RestoreAdvert(ctx context.Context, ID int64, newExpiredAt time.Time) error {
+// TransitAdvert mocks base method.
+func (m *MockDBRepo) TransitAdvert(ctx context.Context, ID int64, fn model.TransitionFunc) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "RestoreAdvert", ctx, ID, newExpiredAt)
+	ret := m.ctrl.Call(m, "TransitAdvert", ctx, ID, fn)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// RestoreAdvert indicates an expected call of RestoreAdvert.
-func (mr *MockDBRepoMockRecorder) RestoreAdvert(ctx, ID, newExpiredAt interface{}) *gomock.Call {
+// TransitAdvert indicates an expected call of TransitAdvert.
+func (mr *MockDBRepoMockRecorder) TransitAdvert(ctx, ID, fn interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreAdvert", reflect.TypeOf((*MockDBRepo)(nil).RestoreAdvert), ctx, ID, newExpiredAt)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TransitAdvert", reflect.TypeOf((*MockDBRepo)(nil).TransitAdvert), ctx, ID, fn)
 }
@@ -0,0 +1,68 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/s21platform/advert-service/internal/config"
+	"github.com/s21platform/advert-service/internal/model"
+)
+
+type Action string
+
+const (
+	ActionCreate   Action = "create"
+	ActionList     Action = "list"
+	ActionRead     Action = "read"
+	ActionManage   Action = "manage"
+	ActionModerate Action = "moderate"
+)
+
+// Policy решает, может ли вызывающий выполнить действие над объявлением владельца ownerUUID
+type Policy interface {
+	Authorize(caller model.Caller, action Action, ownerUUID string) error
+}
+
+// RolePolicy разрешает владельцу управлять своими объявлениями, модератору - читать и модерировать любые,
+// сервисному аккаунту - всё
+type RolePolicy struct{}
+
+func NewRolePolicy() *RolePolicy {
+	return &RolePolicy{}
+}
+
+func (p *RolePolicy) Authorize(caller model.Caller, action Action, ownerUUID string) error {
+	isOwner := ownerUUID != "" && caller.UUID == ownerUUID
+
+	allowed := false
+	switch action {
+	case ActionCreate, ActionList:
+		allowed = true
+	case ActionRead:
+		allowed = isOwner || caller.Role == model.RoleModerator
+	case ActionManage:
+		allowed = isOwner
+	case ActionModerate:
+		allowed = caller.Role == model.RoleModerator
+	}
+
+	if !allowed && caller.Role != model.RoleService {
+		return fmt.Errorf("%w: %s is not allowed for %s", model.ErrPermissionDenied, action, caller.Role)
+	}
+
+	return nil
+}
+
+func callerFromContext(ctx context.Context) (model.Caller, bool) {
+	uuid, ok := ctx.Value(config.KeyUUID).(string)
+	if !ok {
+		return model.Caller{}, false
+	}
+
+	role, ok := ctx.Value(config.KeyRole).(string)
+	if !ok {
+		role = model.RoleUser
+	}
+
+	return model.Caller{UUID: uuid, Role: role}, true
+}
@@ -0,0 +1,84 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/s21platform/advert-service/internal/config"
+	"github.com/s21platform/advert-service/internal/model"
+)
+
+func TestRolePolicy_Authorize(t *testing.T) {
+	t.Parallel()
+
+	owner := model.Caller{UUID: "owner-uuid", Role: model.RoleUser}
+	stranger := model.Caller{UUID: "stranger-uuid", Role: model.RoleUser}
+	moderator := model.Caller{UUID: "moderator-uuid", Role: model.RoleModerator}
+	serviceAccount := model.Caller{UUID: "service-uuid", Role: model.RoleService}
+
+	tests := []struct {
+		name    string
+		caller  model.Caller
+		action  Action
+		allowed bool
+	}{
+		{"owner_create", owner, ActionCreate, true},
+		{"owner_list", owner, ActionList, true},
+		{"owner_read", owner, ActionRead, true},
+		{"owner_manage", owner, ActionManage, true},
+		{"owner_moderate", owner, ActionModerate, false},
+		{"stranger_read", stranger, ActionRead, false},
+		{"stranger_manage", stranger, ActionManage, false},
+		{"moderator_read", moderator, ActionRead, true},
+		{"moderator_manage", moderator, ActionManage, false},
+		{"moderator_moderate", moderator, ActionModerate, true},
+		{"service_manage", serviceAccount, ActionManage, true},
+		{"service_moderate", serviceAccount, ActionModerate, true},
+		{"unknown_action", stranger, Action("delete"), false},
+	}
+
+	p := NewRolePolicy()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := p.Authorize(tt.caller, tt.action, owner.UUID)
+			if tt.allowed {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, model.ErrPermissionDenied)
+			}
+		})
+	}
+
+	t.Run("empty_owner_is_not_matched", func(t *testing.T) {
+		err := p.Authorize(model.Caller{Role: model.RoleUser}, ActionManage, "")
+		assert.ErrorIs(t, err, model.ErrPermissionDenied)
+	})
+}
+
+func TestCallerFromContext(t *testing.T) {
+	t.Parallel()
+
+	t.Run("default_role", func(t *testing.T) {
+		ctx := context.WithValue(context.Background(), config.KeyUUID, "user-uuid")
+
+		caller, ok := callerFromContext(ctx)
+		assert.True(t, ok)
+		assert.Equal(t, model.Caller{UUID: "user-uuid", Role: model.RoleUser}, caller)
+	})
+
+	t.Run("role_from_context", func(t *testing.T) {
+		ctx := context.WithValue(context.Background(), config.KeyUUID, "moderator-uuid")
+		ctx = context.WithValue(ctx, config.KeyRole, model.RoleModerator)
+
+		caller, ok := callerFromContext(ctx)
+		assert.True(t, ok)
+		assert.Equal(t, model.RoleModerator, caller.Role)
+	})
+
+	t.Run("no_uuid", func(t *testing.T) {
+		_, ok := callerFromContext(context.Background())
+		assert.False(t, ok)
+	})
+}
@@ -3,12 +3,14 @@ package service
 import (
 	"context"
 	"fmt"
+	"slices"
 	"time"
 
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 
 	logger_lib "github.com/s21platform/logger-lib"
+	pkg "github.com/s21platform/metrics-lib/pkg"
 
 	"github.com/s21platform/advert-service/internal/config"
 	"github.com/s21platform/advert-service/internal/model"
@@ -17,29 +19,49 @@ import (
 
 type Service struct {
 	advert_api.UnimplementedAdvertServiceServer
-	dbR DBRepo
+	dbR       DBRepo
+	policy    Policy
+	validator *Validator
+	lifecycle config.Lifecycle
 }
 
-func New(dbR DBRepo) *Service {
-	return &Service{dbR: dbR}
+func New(dbR DBRepo, policy Policy, validator *Validator, lifecycle config.Lifecycle) *Service {
+	return &Service{dbR: dbR, policy: policy, validator: validator, lifecycle: lifecycle}
 }
 
 func (s *Service) CreateAdvert(ctx context.Context, in *advert_api.CreateAdvertIn) (*advert_api.AdvertEmpty, error) {
 	logger := logger_lib.FromContext(ctx, config.KeyLogger)
 	logger.AddFuncName("CreateAdvert")
 
-	ownerUUID, ok := ctx.Value(config.KeyUUID).(string)
+	caller, ok := callerFromContext(ctx)
 	if !ok {
 		logger.Error("failed to find uuid")
 		return nil, status.Errorf(codes.Unauthenticated, "failed to retrieve uuid")
 	}
 
-	err := s.dbR.CreateAdvert(ctx, ownerUUID, in)
+	if err := s.policy.Authorize(caller, ActionCreate, caller.UUID); err != nil {
+		logger.Error(fmt.Sprintf("failed to create advert: %v", err))
+		return nil, statusError(err, "failed to create advert")
+	}
+
+	if err := s.validator.CreateAdvert(in); err != nil {
+		logger.Error(fmt.Sprintf("failed to create advert: %v", err))
+		return nil, statusError(err, "failed to create advert")
+	}
+
+	if err := s.checkKnownOs(ctx, "user.os", in.GetUser().GetOs()); err != nil {
+		logger.Error(fmt.Sprintf("failed to create advert: %v", err))
+		return nil, statusError(err, "failed to create advert")
+	}
+
+	err := s.dbR.CreateAdvert(ctx, caller.UUID, in)
 	if err != nil {
 		logger.Error(fmt.Sprintf("failed to create advert: %v", err))
-		return nil, status.Errorf(codes.Internal, "failed to create advert: %v", err)
+		return nil, statusError(err, "failed to create advert")
 	}
 
+	count(ctx, "advert.created")
+
 	return &advert_api.AdvertEmpty{}, nil
 }
 
@@ -47,10 +69,21 @@ func (s *Service) GetAdvert(ctx context.Context, in *advert_api.GetAdvertIn) (*a
 	logger := logger_lib.FromContext(ctx, config.KeyLogger)
 	logger.AddFuncName("GetAdvert")
 
+	caller, ok := callerFromContext(ctx)
+	if !ok {
+		logger.Error("failed to find uuid")
+		return nil, status.Errorf(codes.Unauthenticated, "failed to find uuid")
+	}
+
 	advert, err := s.dbR.GetAdvert(ctx, in)
 	if err != nil {
 		logger.Error(fmt.Sprintf("failed to get advert: %v", err))
-		return nil, status.Errorf(codes.Internal, "failed to get advert: %v", err)
+		return nil, statusError(err, "failed to get advert")
+	}
+
+	if err = s.policy.Authorize(caller, ActionRead, advert.OwnerUUID); err != nil {
+		logger.Error(fmt.Sprintf("failed to get advert: %v", err))
+		return nil, statusError(err, "failed to get advert")
 	}
 
 	return &advert_api.GetAdvertOut{
@@ -58,24 +91,77 @@ func (s *Service) GetAdvert(ctx context.Context, in *advert_api.GetAdvertIn) (*a
 	}, nil
 }
 
-func (s *Service) GetAdverts(ctx context.Context, _ *advert_api.AdvertEmpty) (*advert_api.GetAdvertsOut, error) {
+func (s *Service) GetAdverts(ctx context.Context, in *advert_api.GetAdvertsIn) (*advert_api.GetAdvertsOut, error) {
 	logger := logger_lib.FromContext(ctx, config.KeyLogger)
 	logger.AddFuncName("GetAdverts")
 
-	ownerUUID, ok := ctx.Value(config.KeyUUID).(string)
+	caller, ok := callerFromContext(ctx)
 	if !ok {
 		logger.Error("failed to find uuid")
 		return nil, status.Errorf(codes.Unauthenticated, "failed to find uuid")
 	}
 
-	adverts, err := s.dbR.GetAdverts(ownerUUID)
+	if err := s.policy.Authorize(caller, ActionList, caller.UUID); err != nil {
+		logger.Error(fmt.Sprintf("failed to find adverts: %v", err))
+		return nil, statusError(err, "failed to find adverts")
+	}
+
+	page := &model.AdvertsPage{}
+	if err := page.ToDTO(caller.UUID, in); err != nil {
+		logger.Error(fmt.Sprintf("failed to parse page request: %v", err))
+		return nil, status.Errorf(codes.InvalidArgument, "failed to parse page request: %v", err)
+	}
+
+	result, err := s.dbR.GetAdverts(ctx, page)
 	if err != nil {
 		logger.Error(fmt.Sprintf("failed to find adverts: %v", err))
-		return nil, status.Errorf(codes.Internal, "failed to find adverts: %v", err)
+		return nil, statusError(err, "failed to find adverts")
+	}
+
+	return &advert_api.GetAdvertsOut{
+		Adverts:       result.Adverts.ListFromDTO(),
+		NextPageToken: result.NextPageToken(),
+		TotalCount:    result.TotalCount,
+	}, nil
+}
+
+func (s *Service) GetAdvertsForUser(ctx context.Context, in *advert_api.GetAdvertsForUserIn) (*advert_api.GetAdvertsOut, error) {
+	logger := logger_lib.FromContext(ctx, config.KeyLogger)
+	logger.AddFuncName("GetAdvertsForUser")
+
+	caller, ok := callerFromContext(ctx)
+	if !ok {
+		logger.Error("failed to find uuid")
+		return nil, status.Errorf(codes.Unauthenticated, "failed to find uuid")
+	}
+
+	if err := s.policy.Authorize(caller, ActionList, ""); err != nil {
+		logger.Error(fmt.Sprintf("failed to find adverts for user: %v", err))
+		return nil, statusError(err, "failed to find adverts for user")
+	}
+
+	page := &model.UserAdvertsPage{}
+	if err := page.ToDTO(in); err != nil {
+		logger.Error(fmt.Sprintf("failed to parse page request: %v", err))
+		return nil, status.Errorf(codes.InvalidArgument, "failed to parse page request: %v", err)
 	}
 
+	result, err := s.dbR.GetAdvertsForUser(ctx, page)
+	if err != nil {
+		logger.Error(fmt.Sprintf("failed to find adverts for user: %v", err))
+		return nil, statusError(err, "failed to find adverts for user")
+	}
+
+	names, err := s.dbR.GetAttributeNames(ctx, model.AttributeOs, result.Adverts.OsIDs())
+	if err != nil {
+		logger.Error(fmt.Sprintf("failed to get os names: %v", err))
+		return nil, statusError(err, "failed to get os names")
+	}
+	result.Adverts.SetOsNames(names)
+
 	return &advert_api.GetAdvertsOut{
-		Adverts: adverts.ListFromDTO(),
+		Adverts:       result.Adverts.ListFromDTO(),
+		NextPageToken: result.NextPageToken(),
 	}, nil
 }
 
@@ -83,12 +169,28 @@ func (s *Service) CancelAdvert(ctx context.Context, in *advert_api.CancelAdvertI
 	logger := logger_lib.FromContext(ctx, config.KeyLogger)
 	logger.AddFuncName("CancelAdvert")
 
-	err := s.dbR.CancelAdvert(ctx, in)
+	caller, ok := callerFromContext(ctx)
+	if !ok {
+		logger.Error("failed to find uuid")
+		return nil, status.Errorf(codes.Unauthenticated, "failed to find uuid")
+	}
+
+	err := s.dbR.TransitAdvert(ctx, in.Id, func(advert *model.AdvertLifecycle) (*model.Transition, error) {
+		if err := s.policy.Authorize(caller, ActionManage, advert.OwnerUUID); err != nil {
+			return nil, err
+		}
+		if err := advert.CheckVersion(in.GetExpectedVersion()); err != nil {
+			return nil, err
+		}
+		return &model.Transition{To: model.StatusCanceled}, nil
+	})
 	if err != nil {
 		logger.Error(fmt.Sprintf("failed to cancel advert: %v", err))
-		return nil, status.Errorf(codes.Internal, "failed to cancel advert: %v", err)
+		return nil, statusError(err, "failed to cancel advert")
 	}
 
+	count(ctx, "advert.canceled")
+
 	return &advert_api.AdvertEmpty{}, nil
 }
 
@@ -96,68 +198,405 @@ func (s *Service) RestoreAdvert(ctx context.Context, in *advert_api.RestoreAdver
 	logger := logger_lib.FromContext(ctx, config.KeyLogger)
 	logger.AddFuncName("RestoreAdvert")
 
-	cancelExpiry, err := s.dbR.GetAdvertCancelExpiry(ctx, in.Id)
+	caller, ok := callerFromContext(ctx)
+	if !ok {
+		logger.Error("failed to find uuid")
+		return nil, status.Errorf(codes.Unauthenticated, "failed to find uuid")
+	}
+
+	err := s.dbR.TransitAdvert(ctx, in.Id, func(advert *model.AdvertLifecycle) (*model.Transition, error) {
+		if err := s.policy.Authorize(caller, ActionManage, advert.OwnerUUID); err != nil {
+			return nil, err
+		}
+		if err := advert.CheckVersion(in.GetExpectedVersion()); err != nil {
+			return nil, err
+		}
+
+		if advert.Status != model.StatusCanceled {
+			return nil, model.ErrAdvertNotCanceled
+		}
+		if advert.CanceledAt != nil && time.Since(*advert.CanceledAt) > s.lifecycle.RestoreGraceWindow {
+			return nil, model.ErrRestoreWindowClosed
+		}
+
+		return &model.Transition{To: model.StatusActive}, nil
+	})
 	if err != nil {
-		logger.Error(fmt.Sprintf("failed to get advert cancel info: %v", err))
-		return nil, status.Errorf(codes.Internal, "failed to get advert cancel info: %v", err)
+		logger.Error(fmt.Sprintf("failed to restore advert: %v", err))
+		return nil, statusError(err, "failed to restore advert")
 	}
 
-	if !cancelExpiry.IsCanceled {
-		logger.Error("failed to restore the advert due to a missing cancellation record")
-		return nil, status.Errorf(codes.Internal, "failed to restore the advert due to a missing cancellation record")
+	count(ctx, "advert.restored")
+
+	return &advert_api.AdvertEmpty{}, nil
+}
+
+func (s *Service) EditAdvert(ctx context.Context, in *advert_api.EditAdvertIn) (*advert_api.AdvertEmpty, error) {
+	logger := logger_lib.FromContext(ctx, config.KeyLogger)
+	logger.AddFuncName("EditAdvert")
+
+	caller, ok := callerFromContext(ctx)
+	if !ok {
+		logger.Error("failed to find uuid")
+		return nil, status.Errorf(codes.Unauthenticated, "failed to find uuid")
+	}
+
+	if err := s.validator.EditAdvert(in); err != nil {
+		logger.Error(fmt.Sprintf("failed to edit advert: %v", err))
+		return nil, statusError(err, "failed to edit advert")
 	}
 
-	timeDiff := time.Since(*cancelExpiry.CanceledAt)
-	newExpiredAt := cancelExpiry.ExpiredAt.Add(timeDiff)
+	newAdvertData := &model.EditAdvert{}
+	newAdvertData.ToDTO(caller.UUID, in)
 
-	err = s.dbR.RestoreAdvert(ctx, in.Id, newExpiredAt)
+	if slices.Contains(newAdvertData.Fields, model.EditFieldUserFilter) {
+		if err := s.checkKnownOs(ctx, "user_filter.os", in.GetUserFilter().GetOs()); err != nil {
+			logger.Error(fmt.Sprintf("failed to edit advert: %v", err))
+			return nil, statusError(err, "failed to edit advert")
+		}
+	}
+	err := s.dbR.EditAdvert(ctx, newAdvertData, s.editCheck(caller, in.GetExpectedVersion(), newAdvertData))
 	if err != nil {
-		logger.Error(fmt.Sprintf("failed to restore advert: %v", err))
-		return nil, status.Errorf(codes.Internal, "failed to restore advert: %v", err)
+		logger.Error(fmt.Sprintf("failed to edit advert: %v", err))
+		return nil, statusError(err, "failed to edit advert")
 	}
 
+	count(ctx, "advert.edited")
+
 	return &advert_api.AdvertEmpty{}, nil
 }
 
-func (s *Service) EditAdvert(ctx context.Context, in *advert_api.EditAdvertIn) (*advert_api.AdvertEmpty, error) {
+func (s *Service) BanAdvert(ctx context.Context, in *advert_api.BanAdvertIn) (*advert_api.AdvertEmpty, error) {
 	logger := logger_lib.FromContext(ctx, config.KeyLogger)
-	logger.AddFuncName("EditAdvert")
+	logger.AddFuncName("BanAdvert")
+
+	caller, ok := callerFromContext(ctx)
+	if !ok {
+		logger.Error("failed to find uuid")
+		return nil, status.Errorf(codes.Unauthenticated, "failed to find uuid")
+	}
+
+	if err := s.policy.Authorize(caller, ActionModerate, ""); err != nil {
+		logger.Error(fmt.Sprintf("failed to ban advert: %v", err))
+		return nil, statusError(err, "failed to ban advert")
+	}
+
+	if in.Reason == "" {
+		logger.Error("failed to ban: reason is empty")
+		return nil, status.Errorf(codes.InvalidArgument, "failed to ban: reason is empty")
+	}
+
+	err := s.dbR.TransitAdvert(ctx, in.Id, func(_ *model.AdvertLifecycle) (*model.Transition, error) {
+		return &model.Transition{
+			To:        model.StatusBanned,
+			BanReason: in.Reason,
+			BannedBy:  caller.UUID,
+		}, nil
+	})
+	if err != nil {
+		logger.Error(fmt.Sprintf("failed to ban advert: %v", err))
+		return nil, statusError(err, "failed to ban advert")
+	}
+
+	return &advert_api.AdvertEmpty{}, nil
+}
+
+func (s *Service) UnbanAdvert(ctx context.Context, in *advert_api.UnbanAdvertIn) (*advert_api.AdvertEmpty, error) {
+	logger := logger_lib.FromContext(ctx, config.KeyLogger)
+	logger.AddFuncName("UnbanAdvert")
+
+	caller, ok := callerFromContext(ctx)
+	if !ok {
+		logger.Error("failed to find uuid")
+		return nil, status.Errorf(codes.Unauthenticated, "failed to find uuid")
+	}
 
-	isActive, err := s.dbR.IsAdvertActive(ctx, int(in.Id))
+	if err := s.policy.Authorize(caller, ActionModerate, ""); err != nil {
+		logger.Error(fmt.Sprintf("failed to unban advert: %v", err))
+		return nil, statusError(err, "failed to unban advert")
+	}
+
+	err := s.dbR.TransitAdvert(ctx, in.Id, func(advert *model.AdvertLifecycle) (*model.Transition, error) {
+		if advert.Status != model.StatusBanned {
+			return nil, model.ErrAdvertNotBanned
+		}
+		return &model.Transition{To: model.StatusActive}, nil
+	})
 	if err != nil {
-		logger.Error(fmt.Sprintf("failed to check if the advert is active or not: %v", err))
-		return nil, status.Errorf(codes.Internal, "failed to check if the advert is active or not: %v", err)
+		logger.Error(fmt.Sprintf("failed to unban advert: %v", err))
+		return nil, statusError(err, "failed to unban advert")
 	}
 
-	if !isActive {
-		logger.Error("failed to edit the advert, since it is not active")
-		return nil, status.Errorf(codes.Unavailable, "failed to edit the advert, since it is not active")
+	return &advert_api.AdvertEmpty{}, nil
+}
+
+func (s *Service) ListAdvertRevisions(ctx context.Context, in *advert_api.ListAdvertRevisionsIn) (*advert_api.ListAdvertRevisionsOut, error) {
+	logger := logger_lib.FromContext(ctx, config.KeyLogger)
+	logger.AddFuncName("ListAdvertRevisions")
+
+	caller, ok := callerFromContext(ctx)
+	if !ok {
+		logger.Error("failed to find uuid")
+		return nil, status.Errorf(codes.Unauthenticated, "failed to find uuid")
 	}
 
-	uuid, ok := ctx.Value(config.KeyUUID).(string)
+	advert, err := s.dbR.GetAdvert(ctx, &advert_api.GetAdvertIn{Id: in.AdvertId})
+	if err != nil {
+		logger.Error(fmt.Sprintf("failed to get advert: %v", err))
+		return nil, statusError(err, "failed to get advert")
+	}
+
+	if err = s.policy.Authorize(caller, ActionRead, advert.OwnerUUID); err != nil {
+		logger.Error(fmt.Sprintf("failed to list advert revisions: %v", err))
+		return nil, statusError(err, "failed to list advert revisions")
+	}
+
+	revisions, err := s.dbR.GetAdvertRevisions(ctx, in.AdvertId)
+	if err != nil {
+		logger.Error(fmt.Sprintf("failed to list advert revisions: %v", err))
+		return nil, statusError(err, "failed to list advert revisions")
+	}
+
+	return &advert_api.ListAdvertRevisionsOut{
+		Revisions: revisions.ListFromDTO(),
+	}, nil
+}
+
+func (s *Service) RevertAdvert(ctx context.Context, in *advert_api.RevertAdvertIn) (*advert_api.AdvertEmpty, error) {
+	logger := logger_lib.FromContext(ctx, config.KeyLogger)
+	logger.AddFuncName("RevertAdvert")
+
+	caller, ok := callerFromContext(ctx)
 	if !ok {
 		logger.Error("failed to find uuid")
 		return nil, status.Errorf(codes.Unauthenticated, "failed to find uuid")
 	}
 
-	ownerUUID, err := s.dbR.GetOwnerUUID(ctx, int(in.Id))
+	// права проверяются до загрузки ревизии, чтобы чужой не узнал, какие ревизии существуют
+	advert, err := s.dbR.GetAdvert(ctx, &advert_api.GetAdvertIn{Id: in.AdvertId})
+	if err != nil {
+		logger.Error(fmt.Sprintf("failed to get advert: %v", err))
+		return nil, statusError(err, "failed to get advert")
+	}
+
+	if err = s.policy.Authorize(caller, ActionManage, advert.OwnerUUID); err != nil {
+		logger.Error(fmt.Sprintf("failed to revert advert: %v", err))
+		return nil, statusError(err, "failed to revert advert")
+	}
+
+	revision, err := s.dbR.GetAdvertRevision(ctx, in.AdvertId, in.RevisionId)
 	if err != nil {
-		logger.Error(fmt.Sprintf("failed to get owner uuid: %v", err))
-		return nil, status.Errorf(codes.Internal, "failed to get owner uuid: %v", err)
+		logger.Error(fmt.Sprintf("failed to get advert revision: %v", err))
+		return nil, statusError(err, "failed to get advert revision")
 	}
 
-	if ownerUUID != uuid {
-		logger.Error("failed to edit: user is not owner")
-		return nil, status.Errorf(codes.PermissionDenied, "failed to edit: user is not owner")
+	edit := revision.ToEdit(caller.UUID)
+	err = s.dbR.EditAdvert(ctx, edit, s.editCheck(caller, in.GetExpectedVersion(), edit))
+	if err != nil {
+		logger.Error(fmt.Sprintf("failed to revert advert: %v", err))
+		return nil, statusError(err, "failed to revert advert")
 	}
 
-	newAdvertData := &model.EditAdvert{}
-	newAdvertData.ToDTO(in)
-	err = s.dbR.EditAdvert(ctx, newAdvertData)
+	return &advert_api.AdvertEmpty{}, nil
+}
+
+func (s *Service) PauseAdvert(ctx context.Context, in *advert_api.PauseAdvertIn) (*advert_api.AdvertEmpty, error) {
+	logger := logger_lib.FromContext(ctx, config.KeyLogger)
+	logger.AddFuncName("PauseAdvert")
+
+	caller, ok := callerFromContext(ctx)
+	if !ok {
+		logger.Error("failed to find uuid")
+		return nil, status.Errorf(codes.Unauthenticated, "failed to find uuid")
+	}
+
+	err := s.dbR.TransitAdvert(ctx, in.Id, func(advert *model.AdvertLifecycle) (*model.Transition, error) {
+		if err := s.policy.Authorize(caller, ActionManage, advert.OwnerUUID); err != nil {
+			return nil, err
+		}
+		if err := advert.CheckVersion(in.GetExpectedVersion()); err != nil {
+			return nil, err
+		}
+		return &model.Transition{To: model.StatusPaused}, nil
+	})
 	if err != nil {
-		logger.Error(fmt.Sprintf("failed to edit advert: %v", err))
-		return nil, status.Errorf(codes.Internal, "failed to edit advert: %v", err)
+		logger.Error(fmt.Sprintf("failed to pause advert: %v", err))
+		return nil, statusError(err, "failed to pause advert")
 	}
 
 	return &advert_api.AdvertEmpty{}, nil
 }
+
+func (s *Service) ResumeAdvert(ctx context.Context, in *advert_api.ResumeAdvertIn) (*advert_api.AdvertEmpty, error) {
+	logger := logger_lib.FromContext(ctx, config.KeyLogger)
+	logger.AddFuncName("ResumeAdvert")
+
+	caller, ok := callerFromContext(ctx)
+	if !ok {
+		logger.Error("failed to find uuid")
+		return nil, status.Errorf(codes.Unauthenticated, "failed to find uuid")
+	}
+
+	err := s.dbR.TransitAdvert(ctx, in.Id, func(advert *model.AdvertLifecycle) (*model.Transition, error) {
+		if err := s.policy.Authorize(caller, ActionManage, advert.OwnerUUID); err != nil {
+			return nil, err
+		}
+		if err := advert.CheckVersion(in.GetExpectedVersion()); err != nil {
+			return nil, err
+		}
+		if advert.Status != model.StatusPaused {
+			return nil, model.ErrAdvertNotPaused
+		}
+
+		transition := &model.Transition{To: model.StatusActive}
+		if s.lifecycle.PauseExtendsExpiry && advert.ExpiredAt != nil && advert.PausedAt != nil {
+			if shift := time.Since(advert.LiveSince(*advert.PausedAt)); shift > 0 {
+				newExpiredAt := advert.ExpiredAt.Add(shift)
+				transition.ExpiredAt = &newExpiredAt
+			}
+		}
+
+		return transition, nil
+	})
+	if err != nil {
+		logger.Error(fmt.Sprintf("failed to resume advert: %v", err))
+		return nil, statusError(err, "failed to resume advert")
+	}
+
+	return &advert_api.AdvertEmpty{}, nil
+}
+
+func (s *Service) ExtendAdvert(ctx context.Context, in *advert_api.ExtendAdvertIn) (*advert_api.AdvertEmpty, error) {
+	logger := logger_lib.FromContext(ctx, config.KeyLogger)
+	logger.AddFuncName("ExtendAdvert")
+
+	caller, ok := callerFromContext(ctx)
+	if !ok {
+		logger.Error("failed to find uuid")
+		return nil, status.Errorf(codes.Unauthenticated, "failed to find uuid")
+	}
+
+	if err := s.validator.ExtendAdvert(in); err != nil {
+		logger.Error(fmt.Sprintf("failed to extend advert: %v", err))
+		return nil, statusError(err, "failed to extend advert")
+	}
+
+	err := s.dbR.ExtendAdvert(ctx, in.Id, caller.UUID, func(advert *model.AdvertLifecycle) (*model.Extension, error) {
+		if err := s.policy.Authorize(caller, ActionManage, advert.OwnerUUID); err != nil {
+			return nil, err
+		}
+		if err := advert.CheckVersion(in.GetExpectedVersion()); err != nil {
+			return nil, err
+		}
+		return s.extension(advert, in, time.Now())
+	})
+	if err != nil {
+		logger.Error(fmt.Sprintf("failed to extend advert: %v", err))
+		return nil, statusError(err, "failed to extend advert")
+	}
+
+	return &advert_api.AdvertEmpty{}, nil
+}
+
+// extension рассчитывает новый срок: продление идёт от текущего срока, продление истёкшего - от текущего момента
+func (s *Service) extension(advert *model.AdvertLifecycle, in *advert_api.ExtendAdvertIn, now time.Time) (*model.Extension, error) {
+	ext := &model.Extension{}
+
+	switch advert.Status {
+	case model.StatusActive, model.StatusScheduled, model.StatusPaused:
+	case model.StatusExpired:
+		if advert.ExpiredAt == nil || now.Sub(*advert.ExpiredAt) > s.lifecycle.RenewalGracePeriod {
+			return nil, model.ErrRenewalWindowClosed
+		}
+		ext.Renew = true
+	default:
+		return nil, fmt.Errorf("%w: %s", model.ErrAdvertNotExtendable, advert.Status)
+	}
+
+	if in.GetExpiredAt() != nil {
+		ext.ExpiredAt = in.GetExpiredAt().AsTime()
+	} else {
+		base := now
+		if !ext.Renew && advert.ExpiredAt != nil && advert.ExpiredAt.After(now) {
+			base = *advert.ExpiredAt
+		}
+		ext.ExpiredAt = base.Add(in.GetDuration().AsDuration())
+	}
+
+	if !ext.ExpiredAt.After(now) || (advert.ExpiredAt != nil && !ext.ExpiredAt.After(*advert.ExpiredAt)) {
+		return nil, model.ErrExpiryNotLater
+	}
+
+	if err := s.checkLifetime(advert, ext.ExpiredAt); err != nil {
+		return nil, err
+	}
+
+	return ext, nil
+}
+
+// checkLifetime ограничивает срок от публикации. Начало отсчёта не сдвигается после публикации,
+// потому что editCheck не даёт менять start_at у уже показанного объявления
+func (s *Service) checkLifetime(advert *model.AdvertLifecycle, expiredAt time.Time) error {
+	if advert.CreatedAt == nil || s.lifecycle.MaxAdvertLifetime <= 0 {
+		return nil
+	}
+	if expiredAt.Sub(advert.LiveSince(*advert.CreatedAt)) > s.lifecycle.MaxAdvertLifetime {
+		return fmt.Errorf("%w: at most %s", model.ErrMaxLifetimeExceeded, s.lifecycle.MaxAdvertLifetime)
+	}
+	return nil
+}
+
+// editCheck разрешает менять содержимое только владельцу активного объявления ожидаемой версии.
+// Новый срок проверяется тем же лимитом, что и при продлении
+func (s *Service) editCheck(caller model.Caller, expectedVersion int64, info *model.EditAdvert) model.CheckFunc {
+	return func(advert *model.AdvertLifecycle) error {
+		if err := s.policy.Authorize(caller, ActionManage, advert.OwnerUUID); err != nil {
+			return err
+		}
+		if err := advert.CheckVersion(expectedVersion); err != nil {
+			return err
+		}
+		if !advert.IsEditable() {
+			return model.ErrAdvertNotActive
+		}
+
+		edited := *advert
+		if slices.Contains(info.Fields, model.EditFieldStartAt) {
+			if advert.WentLive(time.Now()) {
+				return model.ErrAdvertAlreadyLive
+			}
+			edited.StartAt = info.StartAt
+		}
+		if slices.Contains(info.Fields, model.EditFieldExpiredAt) {
+			return s.checkLifetime(&edited, *info.ExpiredAt)
+		}
+		return nil
+	}
+}
+
+// checkKnownOs отклоняет os, которых нет в справочнике атрибутов
+func (s *Service) checkKnownOs(ctx context.Context, field string, os []int64) error {
+	if len(os) == 0 {
+		return nil
+	}
+
+	names, err := s.dbR.GetAttributeNames(ctx, model.AttributeOs, os)
+	if err != nil {
+		return fmt.Errorf("failed to get os names: %w", err)
+	}
+
+	var v violations
+	for _, id := range os {
+		if _, ok := names[id]; !ok {
+			v.add(field, "unknown os id %d", id)
+		}
+	}
+	return v.err()
+}
+
+// count увеличивает бизнес-счётчик, если interceptor положил метрики в контекст
+func count(ctx context.Context, name string) {
+	if metrics := pkg.FromContext(ctx, config.KeyMetrics); metrics != nil {
+		metrics.Increment(name)
+	}
+}
@@ -11,14 +11,26 @@ import (
 	"github.com/stretchr/testify/assert"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
 	logger_lib "github.com/s21platform/logger-lib"
+	pkg "github.com/s21platform/metrics-lib/pkg"
 
 	"github.com/s21platform/advert-service/internal/config"
 	"github.com/s21platform/advert-service/internal/model"
+	"github.com/s21platform/advert-service/internal/repository/postgres"
 	advertproto "github.com/s21platform/advert-service/pkg/advert"
 )
 
+var testLifecycle = config.Lifecycle{
+	PauseExtendsExpiry: true,
+	RestoreGraceWindow: 72 * time.Hour,
+	RenewalGracePeriod: 168 * time.Hour,
+	MaxAdvertLifetime:  30 * 24 * time.Hour,
+}
+
 func TestService_GetAdvert(t *testing.T) {
 	t.Parallel()
 
@@ -36,6 +48,7 @@ func TestService_GetAdvert(t *testing.T) {
 
 		expectedAdvert := &model.AdvertInfo{
 			ID:        1,
+			OwnerUUID: uuid,
 			Title:     "политбюро",
 			Content:   "деревянные изделия",
 			ExpiredAt: time.Now(),
@@ -44,12 +57,72 @@ func TestService_GetAdvert(t *testing.T) {
 		mockLogger.EXPECT().AddFuncName("GetAdvert")
 		mockRepo.EXPECT().GetAdvert(ctx, gomock.Any()).Return(expectedAdvert, nil)
 
-		s := New(mockRepo)
+		s := New(mockRepo, NewRolePolicy(), testValidator, testLifecycle)
 		advert, err := s.GetAdvert(ctx, &advertproto.GetAdvertIn{Id: 1})
 		assert.NoError(t, err)
 		assert.Equal(t, &advertproto.GetAdvertOut{Advert: expectedAdvert.FromDTO()}, advert)
 	})
 
+	t.Run("get_moderator_ok", func(t *testing.T) {
+		testCtx := context.WithValue(ctx, config.KeyLogger, mockLogger)
+		testCtx = context.WithValue(testCtx, config.KeyRole, model.RoleModerator)
+
+		expectedAdvert := &model.AdvertInfo{ID: 1, OwnerUUID: "other-uuid"}
+
+		mockLogger.EXPECT().AddFuncName("GetAdvert")
+		mockRepo.EXPECT().GetAdvert(testCtx, gomock.Any()).Return(expectedAdvert, nil)
+
+		s := New(mockRepo, NewRolePolicy(), testValidator, testLifecycle)
+		advert, err := s.GetAdvert(testCtx, &advertproto.GetAdvertIn{Id: 1})
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), advert.Advert.Id)
+	})
+
+	t.Run("get_not_owner", func(t *testing.T) {
+		ctx = context.WithValue(ctx, config.KeyLogger, mockLogger)
+
+		mockLogger.EXPECT().AddFuncName("GetAdvert")
+		mockRepo.EXPECT().GetAdvert(ctx, gomock.Any()).Return(&model.AdvertInfo{ID: 1, OwnerUUID: "other-uuid"}, nil)
+		mockLogger.EXPECT().Error(gomock.Any())
+
+		s := New(mockRepo, NewRolePolicy(), testValidator, testLifecycle)
+		_, err := s.GetAdvert(ctx, &advertproto.GetAdvertIn{Id: 1})
+
+		st, ok := status.FromError(err)
+		assert.True(t, ok)
+		assert.Equal(t, codes.PermissionDenied, st.Code())
+	})
+
+	t.Run("get_no_uuid", func(t *testing.T) {
+		testCtx := context.WithValue(context.Background(), config.KeyLogger, mockLogger)
+
+		mockLogger.EXPECT().AddFuncName("GetAdvert")
+		mockLogger.EXPECT().Error("failed to find uuid")
+
+		s := New(mockRepo, NewRolePolicy(), testValidator, testLifecycle)
+		_, err := s.GetAdvert(testCtx, &advertproto.GetAdvertIn{Id: 1})
+
+		st, ok := status.FromError(err)
+		assert.True(t, ok)
+		assert.Equal(t, codes.Unauthenticated, st.Code())
+	})
+
+	t.Run("get_not_found", func(t *testing.T) {
+		ctx = context.WithValue(ctx, config.KeyLogger, mockLogger)
+		expectedErr := fmt.Errorf("failed to get advert from db: %w", postgres.ErrNotFound)
+
+		mockLogger.EXPECT().AddFuncName("GetAdvert")
+		mockRepo.EXPECT().GetAdvert(ctx, gomock.Any()).Return(nil, expectedErr)
+		mockLogger.EXPECT().Error(fmt.Sprintf("failed to get advert: %v", expectedErr))
+
+		s := New(mockRepo, NewRolePolicy(), testValidator, testLifecycle)
+		_, err := s.GetAdvert(ctx, &advertproto.GetAdvertIn{Id: 1})
+
+		st, ok := status.FromError(err)
+		assert.True(t, ok)
+		assert.Equal(t, codes.NotFound, st.Code())
+	})
+
 	t.Run("get_error", func(t *testing.T) {
 		ctx = context.WithValue(ctx, config.KeyLogger, mockLogger)
 		expectedErr := errors.New("get err")
@@ -58,7 +131,7 @@ func TestService_GetAdvert(t *testing.T) {
 		mockRepo.EXPECT().GetAdvert(ctx, gomock.Any()).Return(nil, expectedErr)
 		mockLogger.EXPECT().Error(fmt.Sprintf("failed to get advert: %v", expectedErr))
 
-		s := New(mockRepo)
+		s := New(mockRepo, NewRolePolicy(), testValidator, testLifecycle)
 		_, err := s.GetAdvert(ctx, &advertproto.GetAdvertIn{Id: 1})
 		assert.Error(t, err)
 	})
@@ -79,24 +152,119 @@ func TestServer_GetAdverts(t *testing.T) {
 	t.Run("get_ok", func(t *testing.T) {
 		ctx = context.WithValue(ctx, config.KeyLogger, mockLogger)
 
-		expectedAdverts := &model.AdvertInfoList{
+		expectedAdverts := model.AdvertInfoList{
 			{
+				ID:        1,
 				Content:   "деревянные изделия",
 				ExpiredAt: time.Now(),
 			},
 			{
+				ID:        2,
 				Content:   "деревянные изделия ручной работы",
 				ExpiredAt: time.Now(),
 			},
 		}
+		nextCursor := expectedAdverts[1].Cursor(model.SortByCreatedAt, false)
 
 		mockLogger.EXPECT().AddFuncName("GetAdverts")
-		mockRepo.EXPECT().GetAdverts(uuid).Return(expectedAdverts, nil)
+		mockRepo.EXPECT().GetAdverts(ctx, &model.AdvertsPage{
+			OwnerUUID: uuid,
+			PageSize:  model.DefaultPageSize,
+			SortBy:    model.SortByCreatedAt,
+			Statuses:  []model.AdvertStatus{},
+		}).Return(&model.AdvertsPageResult{
+			Adverts:    expectedAdverts,
+			NextCursor: nextCursor,
+			TotalCount: 5,
+		}, nil)
+
+		s := New(mockRepo, NewRolePolicy(), testValidator, testLifecycle)
+		adverts, err := s.GetAdverts(ctx, &advertproto.GetAdvertsIn{})
+		assert.NoError(t, err)
+		assert.Equal(t, expectedAdverts.ListFromDTO(), adverts.Adverts)
+		assert.Equal(t, nextCursor.Encode(), adverts.NextPageToken)
+		assert.Equal(t, int64(5), adverts.TotalCount)
+	})
+
+	t.Run("get_next_page", func(t *testing.T) {
+		ctx = context.WithValue(ctx, config.KeyLogger, mockLogger)
+
+		cursor := &model.AdvertsCursor{SortBy: model.SortByTitle, Descending: true, Value: "ярмарка", ID: 7}
 
-		s := New(mockRepo)
-		adverts, err := s.GetAdverts(ctx, &advertproto.AdvertEmpty{})
+		mockLogger.EXPECT().AddFuncName("GetAdverts")
+		mockRepo.EXPECT().GetAdverts(ctx, &model.AdvertsPage{
+			OwnerUUID:  uuid,
+			PageSize:   model.MaxPageSize,
+			SortBy:     model.SortByTitle,
+			Descending: true,
+			Statuses:   []model.AdvertStatus{model.StatusActive, model.StatusPaused},
+			Cursor:     cursor,
+		}).Return(&model.AdvertsPageResult{TotalCount: 101}, nil)
+
+		s := New(mockRepo, NewRolePolicy(), testValidator, testLifecycle)
+		adverts, err := s.GetAdverts(ctx, &advertproto.GetAdvertsIn{
+			PageSize:   1000,
+			PageToken:  cursor.Encode(),
+			SortBy:     advertproto.AdvertSortKey_ADVERT_SORT_KEY_TITLE,
+			Descending: true,
+			Statuses: []advertproto.AdvertStatus{
+				advertproto.AdvertStatus_ADVERT_STATUS_ACTIVE,
+				advertproto.AdvertStatus_ADVERT_STATUS_PAUSED,
+			},
+		})
 		assert.NoError(t, err)
-		assert.Equal(t, adverts, &advertproto.GetAdvertsOut{Adverts: adverts.Adverts})
+		assert.Empty(t, adverts.NextPageToken)
+		assert.Equal(t, int64(101), adverts.TotalCount)
+	})
+
+	t.Run("get_invalid_page_token", func(t *testing.T) {
+		ctx = context.WithValue(ctx, config.KeyLogger, mockLogger)
+
+		mockLogger.EXPECT().AddFuncName("GetAdverts")
+		mockLogger.EXPECT().Error(gomock.Any())
+
+		s := New(mockRepo, NewRolePolicy(), testValidator, testLifecycle)
+		_, err := s.GetAdverts(ctx, &advertproto.GetAdvertsIn{PageToken: "not a token"})
+
+		st, ok := status.FromError(err)
+		assert.True(t, ok)
+		assert.Equal(t, codes.InvalidArgument, st.Code())
+		assert.Contains(t, st.Message(), model.ErrInvalidPageToken.Error())
+	})
+
+	t.Run("get_page_token_sort_mismatch", func(t *testing.T) {
+		ctx = context.WithValue(ctx, config.KeyLogger, mockLogger)
+
+		cursor := &model.AdvertsCursor{SortBy: model.SortByTitle, Value: "ярмарка", ID: 7}
+
+		mockLogger.EXPECT().AddFuncName("GetAdverts")
+		mockLogger.EXPECT().Error(gomock.Any())
+
+		s := New(mockRepo, NewRolePolicy(), testValidator, testLifecycle)
+		_, err := s.GetAdverts(ctx, &advertproto.GetAdvertsIn{
+			PageToken: cursor.Encode(),
+			SortBy:    advertproto.AdvertSortKey_ADVERT_SORT_KEY_EXPIRED_AT,
+		})
+
+		st, ok := status.FromError(err)
+		assert.True(t, ok)
+		assert.Equal(t, codes.InvalidArgument, st.Code())
+	})
+
+	t.Run("get_unknown_status", func(t *testing.T) {
+		ctx = context.WithValue(ctx, config.KeyLogger, mockLogger)
+
+		mockLogger.EXPECT().AddFuncName("GetAdverts")
+		mockLogger.EXPECT().Error(gomock.Any())
+
+		s := New(mockRepo, NewRolePolicy(), testValidator, testLifecycle)
+		_, err := s.GetAdverts(ctx, &advertproto.GetAdvertsIn{
+			Statuses: []advertproto.AdvertStatus{advertproto.AdvertStatus_ADVERT_STATUS_UNSPECIFIED},
+		})
+
+		st, ok := status.FromError(err)
+		assert.True(t, ok)
+		assert.Equal(t, codes.InvalidArgument, st.Code())
 	})
 
 	t.Run("get_no_uuid", func(t *testing.T) {
@@ -110,8 +278,8 @@ func TestServer_GetAdverts(t *testing.T) {
 		ctx = context.WithValue(ctx, config.KeyLogger, mockLogger)
 		mockLogger.EXPECT().Error("failed to find uuid")
 
-		s := New(mockRepo)
-		_, err := s.GetAdverts(ctx, &advertproto.AdvertEmpty{})
+		s := New(mockRepo, NewRolePolicy(), testValidator, testLifecycle)
+		_, err := s.GetAdverts(ctx, &advertproto.GetAdvertsIn{})
 
 		st, ok := status.FromError(err)
 		assert.True(t, ok)
@@ -120,17 +288,16 @@ func TestServer_GetAdverts(t *testing.T) {
 	})
 
 	t.Run("get_err", func(t *testing.T) {
-		expectedAdverts := &model.AdvertInfoList{}
+		ctx = context.WithValue(ctx, config.KeyLogger, mockLogger)
 		expectedErr := errors.New("get err")
 
-		mockRepo.EXPECT().GetAdverts(uuid).Return(expectedAdverts, expectedErr)
+		mockRepo.EXPECT().GetAdverts(ctx, gomock.Any()).Return(nil, expectedErr)
 
 		mockLogger.EXPECT().AddFuncName("GetAdverts")
-		ctx = context.WithValue(ctx, config.KeyLogger, mockLogger)
 		mockLogger.EXPECT().Error(fmt.Sprintf("failed to find adverts: %v", expectedErr))
 
-		s := New(mockRepo)
-		_, err := s.GetAdverts(ctx, &advertproto.AdvertEmpty{})
+		s := New(mockRepo, NewRolePolicy(), testValidator, testLifecycle)
+		_, err := s.GetAdverts(ctx, &advertproto.GetAdvertsIn{})
 
 		st, ok := status.FromError(err)
 		assert.True(t, ok)
@@ -139,6 +306,130 @@ func TestServer_GetAdverts(t *testing.T) {
 	})
 }
 
+func TestService_GetAdvertsForUser(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	uuid := "test-uuid"
+	ctx = context.WithValue(ctx, config.KeyUUID, uuid)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockRepo := NewMockDBRepo(ctrl)
+	mockLogger := logger_lib.NewMockLoggerInterface(ctrl)
+
+	t.Run("get_ok", func(t *testing.T) {
+		ctx = context.WithValue(ctx, config.KeyLogger, mockLogger)
+
+		expectedAdverts := &model.AdvertInfoList{
+			{
+				ID:        1,
+				Title:     "хакатон",
+				Content:   "для пользователей macOS",
+				ExpiredAt: time.Now(),
+				Filter:    model.UserFilter{Os: []int64{2}},
+			},
+		}
+
+		mockLogger.EXPECT().AddFuncName("GetAdvertsForUser")
+		mockRepo.EXPECT().GetAdvertsForUser(ctx, &model.UserAdvertsPage{Attrs: model.UserAttributes{Os: 2}, PageSize: model.DefaultPageSize}).
+			Return(&model.AdvertsPageResult{Adverts: *expectedAdverts}, nil)
+		mockRepo.EXPECT().GetAttributeNames(ctx, model.AttributeOs, []int64{2}).Return(model.AttributeNames{2: "macOS"}, nil)
+
+		s := New(mockRepo, NewRolePolicy(), testValidator, testLifecycle)
+		adverts, err := s.GetAdvertsForUser(ctx, &advertproto.GetAdvertsForUserIn{
+			User: &advertproto.UserAttributes{Os: 2},
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, &advertproto.GetAdvertsOut{Adverts: expectedAdverts.ListFromDTO()}, adverts)
+		assert.Equal(t, []*advertproto.AttributeValue{{Id: 2, Name: "macOS"}}, adverts.Adverts[0].Os)
+	})
+
+	t.Run("get_no_user_attributes", func(t *testing.T) {
+		ctx = context.WithValue(ctx, config.KeyLogger, mockLogger)
+
+		mockLogger.EXPECT().AddFuncName("GetAdvertsForUser")
+		mockRepo.EXPECT().GetAdvertsForUser(ctx, &model.UserAdvertsPage{PageSize: model.DefaultPageSize}).Return(&model.AdvertsPageResult{}, nil)
+		mockRepo.EXPECT().GetAttributeNames(ctx, model.AttributeOs, gomock.Len(0)).Return(model.AttributeNames{}, nil)
+
+		s := New(mockRepo, NewRolePolicy(), testValidator, testLifecycle)
+		adverts, err := s.GetAdvertsForUser(ctx, &advertproto.GetAdvertsForUserIn{})
+		assert.NoError(t, err)
+		assert.Empty(t, adverts.Adverts)
+	})
+
+	t.Run("get_next_page", func(t *testing.T) {
+		ctx = context.WithValue(ctx, config.KeyLogger, mockLogger)
+
+		cursor := &model.AdvertsCursor{SortBy: model.SortByCreatedAt, Descending: true, Value: "2024-01-01T00:00:00Z", ID: 5}
+		next := &model.AdvertsCursor{SortBy: model.SortByCreatedAt, Descending: true, Value: "2023-12-01T00:00:00Z", ID: 3}
+
+		mockLogger.EXPECT().AddFuncName("GetAdvertsForUser")
+		mockRepo.EXPECT().GetAdvertsForUser(ctx, &model.UserAdvertsPage{PageSize: model.MaxPageSize, Cursor: cursor}).
+			Return(&model.AdvertsPageResult{NextCursor: next}, nil)
+		mockRepo.EXPECT().GetAttributeNames(ctx, model.AttributeOs, gomock.Len(0)).Return(model.AttributeNames{}, nil)
+
+		s := New(mockRepo, NewRolePolicy(), testValidator, testLifecycle)
+		adverts, err := s.GetAdvertsForUser(ctx, &advertproto.GetAdvertsForUserIn{
+			PageSize:  1000,
+			PageToken: cursor.Encode(),
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, next.Encode(), adverts.NextPageToken)
+	})
+
+	t.Run("get_foreign_page_token", func(t *testing.T) {
+		ctx = context.WithValue(ctx, config.KeyLogger, mockLogger)
+
+		// токен GetAdverts с другой сортировкой не подходит ленте
+		cursor := &model.AdvertsCursor{SortBy: model.SortByTitle, Value: "a", ID: 1}
+
+		mockLogger.EXPECT().AddFuncName("GetAdvertsForUser")
+		mockLogger.EXPECT().Error(gomock.Any())
+
+		s := New(mockRepo, NewRolePolicy(), testValidator, testLifecycle)
+		_, err := s.GetAdvertsForUser(ctx, &advertproto.GetAdvertsForUserIn{PageToken: cursor.Encode()})
+
+		st, ok := status.FromError(err)
+		assert.True(t, ok)
+		assert.Equal(t, codes.InvalidArgument, st.Code())
+	})
+
+	t.Run("get_err", func(t *testing.T) {
+		ctx = context.WithValue(ctx, config.KeyLogger, mockLogger)
+		expectedErr := errors.New("get err")
+
+		mockLogger.EXPECT().AddFuncName("GetAdvertsForUser")
+		mockRepo.EXPECT().GetAdvertsForUser(ctx, gomock.Any()).Return(nil, expectedErr)
+		mockLogger.EXPECT().Error(fmt.Sprintf("failed to find adverts for user: %v", expectedErr))
+
+		s := New(mockRepo, NewRolePolicy(), testValidator, testLifecycle)
+		_, err := s.GetAdvertsForUser(ctx, &advertproto.GetAdvertsForUserIn{})
+
+		st, ok := status.FromError(err)
+		assert.True(t, ok)
+		assert.Equal(t, codes.Internal, st.Code())
+		assert.Contains(t, st.Message(), "failed to find adverts for user: get err")
+	})
+
+	t.Run("get_names_err", func(t *testing.T) {
+		ctx = context.WithValue(ctx, config.KeyLogger, mockLogger)
+		expectedErr := errors.New("names err")
+
+		mockLogger.EXPECT().AddFuncName("GetAdvertsForUser")
+		mockRepo.EXPECT().GetAdvertsForUser(ctx, gomock.Any()).Return(&model.AdvertsPageResult{}, nil)
+		mockRepo.EXPECT().GetAttributeNames(ctx, model.AttributeOs, gomock.Any()).Return(nil, expectedErr)
+		mockLogger.EXPECT().Error(fmt.Sprintf("failed to get os names: %v", expectedErr))
+
+		s := New(mockRepo, NewRolePolicy(), testValidator, testLifecycle)
+		_, err := s.GetAdvertsForUser(ctx, &advertproto.GetAdvertsForUserIn{})
+
+		st, ok := status.FromError(err)
+		assert.True(t, ok)
+		assert.Equal(t, codes.Internal, st.Code())
+	})
+}
+
 func TestServer_CreateAdverts(t *testing.T) {
 	t.Parallel()
 
@@ -152,14 +443,37 @@ func TestServer_CreateAdverts(t *testing.T) {
 
 	mockLogger := logger_lib.NewMockLoggerInterface(ctrl)
 
+	in := &advertproto.CreateAdvertIn{
+		Title:       "политбюро",
+		TextContent: "деревянные изделия",
+		User:        &advertproto.UserFilter{Os: []int64{1, 2}},
+		ExpiredAt:   timestamppb.New(time.Now().Add(24 * time.Hour)),
+	}
+
 	t.Run("create_ok", func(t *testing.T) {
 		ctx = context.WithValue(ctx, config.KeyLogger, mockLogger)
 
+		mockRepo.EXPECT().GetAttributeNames(ctx, model.AttributeOs, []int64{1, 2}).Return(model.AttributeNames{1: "Windows", 2: "macOS"}, nil)
 		mockRepo.EXPECT().CreateAdvert(ctx, uuid, gomock.Any()).Return(nil)
 		mockLogger.EXPECT().AddFuncName("CreateAdvert")
 
-		s := New(mockRepo)
-		_, err := s.CreateAdvert(ctx, &advertproto.CreateAdvertIn{})
+		s := New(mockRepo, NewRolePolicy(), testValidator, testLifecycle)
+		_, err := s.CreateAdvert(ctx, in)
+		assert.NoError(t, err)
+	})
+
+	t.Run("create_counts_metric", func(t *testing.T) {
+		mockMetrics := pkg.NewMockMetricInterface(ctrl)
+		ctx := context.WithValue(ctx, config.KeyLogger, mockLogger)
+		ctx = context.WithValue(ctx, config.KeyMetrics, mockMetrics)
+
+		mockRepo.EXPECT().GetAttributeNames(ctx, model.AttributeOs, []int64{1, 2}).Return(model.AttributeNames{1: "Windows", 2: "macOS"}, nil)
+		mockRepo.EXPECT().CreateAdvert(ctx, uuid, gomock.Any()).Return(nil)
+		mockLogger.EXPECT().AddFuncName("CreateAdvert")
+		mockMetrics.EXPECT().Increment("advert.created")
+
+		s := New(mockRepo, NewRolePolicy(), testValidator, testLifecycle)
+		_, err := s.CreateAdvert(ctx, in)
 		assert.NoError(t, err)
 	})
 
@@ -174,8 +488,8 @@ func TestServer_CreateAdverts(t *testing.T) {
 		ctx = context.WithValue(ctx, config.KeyLogger, mockLogger)
 		mockLogger.EXPECT().Error("failed to find uuid")
 
-		s := New(mockRepo)
-		_, err := s.CreateAdvert(ctx, &advertproto.CreateAdvertIn{})
+		s := New(mockRepo, NewRolePolicy(), testValidator, testLifecycle)
+		_, err := s.CreateAdvert(ctx, in)
 
 		st, ok := status.FromError(err)
 		assert.True(t, ok)
@@ -186,25 +500,85 @@ func TestServer_CreateAdverts(t *testing.T) {
 	t.Run("create_err", func(t *testing.T) {
 		expectedErr := errors.New("get err")
 
-		mockRepo.EXPECT().CreateAdvert(ctx, uuid, &advertproto.CreateAdvertIn{}).Return(expectedErr)
+		mockRepo.EXPECT().GetAttributeNames(ctx, model.AttributeOs, []int64{1, 2}).Return(model.AttributeNames{1: "Windows", 2: "macOS"}, nil)
+		mockRepo.EXPECT().CreateAdvert(ctx, uuid, in).Return(expectedErr)
 		mockLogger.EXPECT().AddFuncName("CreateAdvert")
 		mockLogger.EXPECT().Error(fmt.Sprintf("failed to create advert: %v", expectedErr))
 
-		s := New(mockRepo)
-		_, err := s.CreateAdvert(ctx, &advertproto.CreateAdvertIn{})
+		s := New(mockRepo, NewRolePolicy(), testValidator, testLifecycle)
+		_, err := s.CreateAdvert(ctx, in)
 
 		st, ok := status.FromError(err)
 		assert.True(t, ok)
 		assert.Equal(t, codes.Internal, st.Code())
 		assert.Contains(t, st.Message(), "failed to create advert: get err")
 	})
+
+	t.Run("create_invalid", func(t *testing.T) {
+		mockLogger.EXPECT().AddFuncName("CreateAdvert")
+		mockLogger.EXPECT().Error(gomock.Any())
+
+		s := New(mockRepo, NewRolePolicy(), testValidator, testLifecycle)
+		_, err := s.CreateAdvert(ctx, &advertproto.CreateAdvertIn{})
+
+		st, ok := status.FromError(err)
+		assert.True(t, ok)
+		assert.Equal(t, codes.InvalidArgument, st.Code())
+		assert.Len(t, st.Details(), 1)
+	})
+
+	t.Run("create_unknown_os", func(t *testing.T) {
+		mockRepo.EXPECT().GetAttributeNames(ctx, model.AttributeOs, []int64{1, 2}).Return(model.AttributeNames{1: "Windows"}, nil)
+		mockLogger.EXPECT().AddFuncName("CreateAdvert")
+		mockLogger.EXPECT().Error(gomock.Any())
+
+		s := New(mockRepo, NewRolePolicy(), testValidator, testLifecycle)
+		_, err := s.CreateAdvert(ctx, in)
+
+		st, ok := status.FromError(err)
+		assert.True(t, ok)
+		assert.Equal(t, codes.InvalidArgument, st.Code())
+		assert.Contains(t, st.Message(), "unknown os id 2")
+	})
+}
+
+// transitWith эмулирует TransitAdvert репозитория над заданным состоянием объявления
+func transitWith(advert *model.AdvertLifecycle, check func(*model.Transition)) func(context.Context, int64, model.TransitionFunc) error {
+	return func(_ context.Context, _ int64, fn model.TransitionFunc) error {
+		transition, err := fn(advert)
+		if err != nil {
+			return err
+		}
+		if !advert.Status.CanTransitionTo(transition.To) {
+			return fmt.Errorf("%w: %s -> %s", model.ErrForbiddenTransition, advert.Status, transition.To)
+		}
+		if check != nil {
+			check(transition)
+		}
+		return nil
+	}
+}
+
+// editWith эмулирует EditAdvert репозитория над заданным состоянием объявления
+func editWith(advert *model.AdvertLifecycle, check func(*model.EditAdvert)) func(context.Context, *model.EditAdvert, model.CheckFunc) error {
+	return func(_ context.Context, info *model.EditAdvert, fn model.CheckFunc) error {
+		if err := fn(advert); err != nil {
+			return err
+		}
+		if check != nil {
+			check(info)
+		}
+		return nil
+	}
 }
 
 func TestServer_RestoreAdvert(t *testing.T) {
 	t.Parallel()
 
 	ctx := context.Background()
+	uuid := "test-uuid"
 	ID := int64(123)
+	ctx = context.WithValue(ctx, config.KeyUUID, uuid)
 
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -215,87 +589,102 @@ func TestServer_RestoreAdvert(t *testing.T) {
 	t.Run("should_return_ok", func(t *testing.T) {
 		ctx = context.WithValue(ctx, config.KeyLogger, mockLogger)
 
-		baseTime := time.Date(2025, 3, 4, 21, 0, 0, 0, time.UTC)
-		canceledAt := baseTime
-		expiredAt := baseTime.Add(1 * time.Hour)
+		canceledAt := time.Now().Add(-2 * time.Hour)
+		expiredAt := canceledAt.Add(1 * time.Hour)
 
-		expectedCancelExpiry := model.AdvertCancelExpiry{
-			IsCanceled: true,
+		advert := &model.AdvertLifecycle{
+			ID:         ID,
+			OwnerUUID:  uuid,
+			Status:     model.StatusCanceled,
 			CanceledAt: &canceledAt,
 			ExpiredAt:  &expiredAt,
 		}
 
 		mockLogger.EXPECT().AddFuncName("RestoreAdvert").Times(1)
-		mockRepo.EXPECT().GetAdvertCancelExpiry(ctx, ID).Return(&expectedCancelExpiry, nil)
-		mockRepo.EXPECT().RestoreAdvert(ctx, ID, gomock.Any()).Return(nil)
+		mockRepo.EXPECT().TransitAdvert(ctx, ID, gomock.Any()).DoAndReturn(transitWith(advert, func(transition *model.Transition) {
+			assert.Equal(t, model.StatusActive, transition.To)
+			assert.Nil(t, transition.ExpiredAt)
+		}))
 
-		s := New(mockRepo)
+		s := New(mockRepo, NewRolePolicy(), testValidator, testLifecycle)
 		result, err := s.RestoreAdvert(ctx, &advertproto.RestoreAdvertIn{Id: ID})
 
 		assert.NoError(t, err)
 		assert.Equal(t, result, &advertproto.AdvertEmpty{})
 	})
 
-	t.Run("should_return_err_cancel_expiry", func(t *testing.T) {
-		expectedErr := errors.New("err")
-		mockRepo.EXPECT().GetAdvertCancelExpiry(ctx, ID).Return(nil, expectedErr)
+	t.Run("should_return_err_restore_window_closed", func(t *testing.T) {
+		canceledAt := time.Now().Add(-testLifecycle.RestoreGraceWindow - time.Hour)
+		advert := &model.AdvertLifecycle{ID: ID, OwnerUUID: uuid, Status: model.StatusCanceled, CanceledAt: &canceledAt}
 
 		mockLogger.EXPECT().AddFuncName("RestoreAdvert").Times(1)
-		mockLogger.EXPECT().Error(fmt.Sprintf("failed to get advert cancel info: %v", expectedErr))
+		mockRepo.EXPECT().TransitAdvert(ctx, ID, gomock.Any()).DoAndReturn(transitWith(advert, nil))
+		mockLogger.EXPECT().Error("failed to restore advert: advert restore window is closed")
 
-		s := New(mockRepo)
+		s := New(mockRepo, NewRolePolicy(), testValidator, testLifecycle)
 		_, err := s.RestoreAdvert(ctx, &advertproto.RestoreAdvertIn{Id: ID})
 
 		st, ok := status.FromError(err)
 		assert.True(t, ok)
-		assert.Equal(t, codes.Internal, st.Code())
-		assert.Contains(t, st.Message(), "err")
+		assert.Equal(t, codes.FailedPrecondition, st.Code())
 	})
 
 	t.Run("should_return_err_was_not_canceled", func(t *testing.T) {
-		baseTime := time.Date(2025, 3, 4, 21, 0, 0, 0, time.UTC)
-		expiredAt := baseTime.Add(1 * time.Hour)
+		advert := &model.AdvertLifecycle{ID: ID, OwnerUUID: uuid, Status: model.StatusActive}
 
-		expectedCancelExpiry := model.AdvertCancelExpiry{
-			IsCanceled: false,
-			CanceledAt: nil,
-			ExpiredAt:  &expiredAt,
-		}
+		mockRepo.EXPECT().TransitAdvert(ctx, ID, gomock.Any()).DoAndReturn(transitWith(advert, nil))
+
+		mockLogger.EXPECT().AddFuncName("RestoreAdvert").Times(1)
+		mockLogger.EXPECT().Error("failed to restore advert: advert is not canceled")
+
+		s := New(mockRepo, NewRolePolicy(), testValidator, testLifecycle)
+		_, err := s.RestoreAdvert(ctx, &advertproto.RestoreAdvertIn{Id: ID})
 
-		mockRepo.EXPECT().GetAdvertCancelExpiry(ctx, ID).Return(&expectedCancelExpiry, nil)
+		st, ok := status.FromError(err)
+		assert.True(t, ok)
+		assert.Equal(t, codes.FailedPrecondition, st.Code())
+		assert.Contains(t, st.Message(), "failed to restore advert: advert is not canceled")
+	})
+
+	t.Run("should_return_err_not_owner", func(t *testing.T) {
+		advert := &model.AdvertLifecycle{ID: ID, OwnerUUID: "other-uuid", Status: model.StatusCanceled}
+
+		mockRepo.EXPECT().TransitAdvert(ctx, ID, gomock.Any()).DoAndReturn(transitWith(advert, nil))
 
 		mockLogger.EXPECT().AddFuncName("RestoreAdvert").Times(1)
-		mockLogger.EXPECT().Error("failed to restore the advert due to a missing cancellation record")
+		mockLogger.EXPECT().Error(gomock.Any())
 
-		s := New(mockRepo)
+		s := New(mockRepo, NewRolePolicy(), testValidator, testLifecycle)
 		_, err := s.RestoreAdvert(ctx, &advertproto.RestoreAdvertIn{Id: ID})
 
 		st, ok := status.FromError(err)
 		assert.True(t, ok)
-		assert.Equal(t, codes.Internal, st.Code())
-		assert.Contains(t, st.Message(), "failed to restore the advert due to a missing cancellation record")
+		assert.Equal(t, codes.PermissionDenied, st.Code())
 	})
 
-	t.Run("should_return_err_restore_advert", func(t *testing.T) {
-		expectedErr := errors.New("err")
+	t.Run("should_return_err_no_uuid", func(t *testing.T) {
+		testCtx := context.WithValue(context.Background(), config.KeyLogger, mockLogger)
+
+		mockLogger.EXPECT().AddFuncName("RestoreAdvert").Times(1)
+		mockLogger.EXPECT().Error("failed to find uuid")
 
-		baseTime := time.Date(2025, 3, 4, 21, 0, 0, 0, time.UTC)
-		canceledAt := baseTime
-		expiredAt := baseTime.Add(1 * time.Hour)
+		s := New(mockRepo, NewRolePolicy(), testValidator, testLifecycle)
+		_, err := s.RestoreAdvert(testCtx, &advertproto.RestoreAdvertIn{Id: ID})
 
-		expectedCancelExpiry := model.AdvertCancelExpiry{
-			IsCanceled: true,
-			CanceledAt: &canceledAt,
-			ExpiredAt:  &expiredAt,
-		}
+		st, ok := status.FromError(err)
+		assert.True(t, ok)
+		assert.Equal(t, codes.Unauthenticated, st.Code())
+	})
 
-		mockRepo.EXPECT().GetAdvertCancelExpiry(ctx, ID).Return(&expectedCancelExpiry, nil)
-		mockRepo.EXPECT().RestoreAdvert(ctx, ID, gomock.Any()).Return(expectedErr)
+	t.Run("should_return_err_restore_advert", func(t *testing.T) {
+		expectedErr := errors.New("err")
+
+		mockRepo.EXPECT().TransitAdvert(ctx, ID, gomock.Any()).Return(expectedErr)
 
 		mockLogger.EXPECT().AddFuncName("RestoreAdvert").Times(1)
 		mockLogger.EXPECT().Error(fmt.Sprintf("failed to restore advert: %v", expectedErr))
 
-		s := New(mockRepo)
+		s := New(mockRepo, NewRolePolicy(), testValidator, testLifecycle)
 		_, err := s.RestoreAdvert(ctx, &advertproto.RestoreAdvertIn{Id: ID})
 
 		st, ok := status.FromError(err)
@@ -310,6 +699,7 @@ func TestService_CancelAdvert(t *testing.T) {
 
 	ctx := context.Background()
 	uuid := "test-uuid"
+	ID := int64(123)
 	ctx = context.WithValue(ctx, config.KeyUUID, uuid)
 
 	ctrl := gomock.NewController(t)
@@ -320,119 +710,367 @@ func TestService_CancelAdvert(t *testing.T) {
 
 	t.Run("cancel_ok", func(t *testing.T) {
 		ctx = context.WithValue(ctx, config.KeyLogger, mockLogger)
+		advert := &model.AdvertLifecycle{ID: ID, OwnerUUID: uuid, Status: model.StatusActive}
 
 		mockLogger.EXPECT().AddFuncName("CancelAdvert").Times(1)
-		mockRepo.EXPECT().CancelAdvert(ctx, gomock.Any()).Return(nil)
+		mockRepo.EXPECT().TransitAdvert(ctx, ID, gomock.Any()).DoAndReturn(transitWith(advert, func(transition *model.Transition) {
+			assert.Equal(t, model.StatusCanceled, transition.To)
+		}))
 
-		s := New(mockRepo)
-		_, err := s.CancelAdvert(ctx, &advertproto.CancelAdvertIn{})
+		s := New(mockRepo, NewRolePolicy(), testValidator, testLifecycle)
+		_, err := s.CancelAdvert(ctx, &advertproto.CancelAdvertIn{Id: ID})
 		assert.NoError(t, err)
 	})
 
-	t.Run("cancel_error", func(t *testing.T) {
-		expectedErr := errors.New("cancel err")
+	t.Run("cancel_version_ok", func(t *testing.T) {
+		advert := &model.AdvertLifecycle{ID: ID, OwnerUUID: uuid, Status: model.StatusActive, Version: 3}
+
+		mockLogger.EXPECT().AddFuncName("CancelAdvert").Times(1)
+		mockRepo.EXPECT().TransitAdvert(ctx, ID, gomock.Any()).DoAndReturn(transitWith(advert, nil))
 
-		mockRepo.EXPECT().CancelAdvert(ctx, gomock.Any()).Return(expectedErr)
+		s := New(mockRepo, NewRolePolicy(), testValidator, testLifecycle)
+		_, err := s.CancelAdvert(ctx, &advertproto.CancelAdvertIn{Id: ID, ExpectedVersion: 3})
+		assert.NoError(t, err)
+	})
+
+	t.Run("cancel_version_mismatch", func(t *testing.T) {
+		advert := &model.AdvertLifecycle{ID: ID, OwnerUUID: uuid, Status: model.StatusActive, Version: 4}
 
 		mockLogger.EXPECT().AddFuncName("CancelAdvert").Times(1)
-		mockLogger.EXPECT().Error(fmt.Sprintf("failed to cancel advert: %v", expectedErr))
+		mockRepo.EXPECT().TransitAdvert(ctx, ID, gomock.Any()).DoAndReturn(transitWith(advert, nil))
+		mockLogger.EXPECT().Error("failed to cancel advert: advert version mismatch: expected 3, actual 4")
 
-		s := New(mockRepo)
-		_, err := s.CancelAdvert(ctx, &advertproto.CancelAdvertIn{})
+		s := New(mockRepo, NewRolePolicy(), testValidator, testLifecycle)
+		_, err := s.CancelAdvert(ctx, &advertproto.CancelAdvertIn{Id: ID, ExpectedVersion: 3})
 
 		st, ok := status.FromError(err)
 		assert.True(t, ok)
-		assert.Equal(t, codes.Internal, st.Code())
-		assert.Contains(t, st.Message(), "failed to cancel advert: cancel err")
+		assert.Equal(t, codes.Aborted, st.Code())
 	})
-}
 
-func TestServer_EditAdvert(t *testing.T) {
-	t.Parallel()
+	t.Run("cancel_forbidden_transition", func(t *testing.T) {
+		advert := &model.AdvertLifecycle{ID: ID, OwnerUUID: uuid, Status: model.StatusExpired}
 
-	ctx := context.Background()
-	ID := int32(123)
+		mockLogger.EXPECT().AddFuncName("CancelAdvert").Times(1)
+		mockRepo.EXPECT().TransitAdvert(ctx, ID, gomock.Any()).DoAndReturn(transitWith(advert, nil))
+		mockLogger.EXPECT().Error(gomock.Any())
 
-	ctrl := gomock.NewController(t)
-	defer ctrl.Finish()
-	mockRepo := NewMockDBRepo(ctrl)
+		s := New(mockRepo, NewRolePolicy(), testValidator, testLifecycle)
+		_, err := s.CancelAdvert(ctx, &advertproto.CancelAdvertIn{Id: ID})
 
-	mockLogger := logger_lib.NewMockLoggerInterface(ctrl)
+		st, ok := status.FromError(err)
+		assert.True(t, ok)
+		assert.Equal(t, codes.FailedPrecondition, st.Code())
+		assert.Contains(t, st.Message(), model.ErrForbiddenTransition.Error())
+	})
 
-	t.Run("should_return_ok", func(t *testing.T) {
-		testCtx := context.WithValue(ctx, config.KeyLogger, mockLogger)
-		testCtx = context.WithValue(testCtx, config.KeyUUID, "user123")
+	t.Run("cancel_not_owner", func(t *testing.T) {
+		advert := &model.AdvertLifecycle{ID: ID, OwnerUUID: "other-uuid", Status: model.StatusActive}
 
-		input := &advertproto.EditAdvertIn{
-			Id:          ID,
-			TextContent: "updated content",
-			UserFilter:  &advertproto.UserFilter{Os: []int64{22}},
-		}
+		mockLogger.EXPECT().AddFuncName("CancelAdvert").Times(1)
+		mockRepo.EXPECT().TransitAdvert(ctx, ID, gomock.Any()).DoAndReturn(transitWith(advert, nil))
+		mockLogger.EXPECT().Error(gomock.Any())
 
-		mockLogger.EXPECT().AddFuncName("EditAdvert").Times(1)
-		mockRepo.EXPECT().IsAdvertActive(testCtx, int(ID)).Return(true, nil)
-		mockRepo.EXPECT().GetOwnerUUID(testCtx, int(ID)).Return("user123", nil)
-		mockRepo.EXPECT().EditAdvert(testCtx, gomock.Any()).DoAndReturn(func(_ context.Context, advert *model.EditAdvert) error {
-			assert.Equal(t, int(ID), advert.ID)
-			assert.Equal(t, "updated content", advert.TextContent)
-			assert.Equal(t, []int64{22}, advert.UserFilter.Os)
-			return nil
-		})
+		s := New(mockRepo, NewRolePolicy(), testValidator, testLifecycle)
+		_, err := s.CancelAdvert(ctx, &advertproto.CancelAdvertIn{Id: ID})
+
+		st, ok := status.FromError(err)
+		assert.True(t, ok)
+		assert.Equal(t, codes.PermissionDenied, st.Code())
+	})
+
+	t.Run("cancel_service_account", func(t *testing.T) {
+		testCtx := context.WithValue(ctx, config.KeyRole, model.RoleService)
+		advert := &model.AdvertLifecycle{ID: ID, OwnerUUID: "other-uuid", Status: model.StatusActive}
+
+		mockLogger.EXPECT().AddFuncName("CancelAdvert").Times(1)
+		mockRepo.EXPECT().TransitAdvert(testCtx, ID, gomock.Any()).DoAndReturn(transitWith(advert, nil))
+
+		s := New(mockRepo, NewRolePolicy(), testValidator, testLifecycle)
+		_, err := s.CancelAdvert(testCtx, &advertproto.CancelAdvertIn{Id: ID})
+		assert.NoError(t, err)
+	})
+
+	t.Run("cancel_no_uuid", func(t *testing.T) {
+		testCtx := context.WithValue(context.Background(), config.KeyLogger, mockLogger)
+
+		mockLogger.EXPECT().AddFuncName("CancelAdvert").Times(1)
+		mockLogger.EXPECT().Error("failed to find uuid")
+
+		s := New(mockRepo, NewRolePolicy(), testValidator, testLifecycle)
+		_, err := s.CancelAdvert(testCtx, &advertproto.CancelAdvertIn{Id: ID})
+
+		st, ok := status.FromError(err)
+		assert.True(t, ok)
+		assert.Equal(t, codes.Unauthenticated, st.Code())
+	})
+
+	t.Run("cancel_error", func(t *testing.T) {
+		expectedErr := errors.New("cancel err")
+
+		mockRepo.EXPECT().TransitAdvert(ctx, ID, gomock.Any()).Return(expectedErr)
+
+		mockLogger.EXPECT().AddFuncName("CancelAdvert").Times(1)
+		mockLogger.EXPECT().Error(fmt.Sprintf("failed to cancel advert: %v", expectedErr))
+
+		s := New(mockRepo, NewRolePolicy(), testValidator, testLifecycle)
+		_, err := s.CancelAdvert(ctx, &advertproto.CancelAdvertIn{Id: ID})
+
+		st, ok := status.FromError(err)
+		assert.True(t, ok)
+		assert.Equal(t, codes.Internal, st.Code())
+		assert.Contains(t, st.Message(), "failed to cancel advert: cancel err")
+	})
+}
+
+func TestServer_EditAdvert(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	ID := int64(123)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockRepo := NewMockDBRepo(ctrl)
+
+	mockLogger := logger_lib.NewMockLoggerInterface(ctrl)
+
+	t.Run("should_return_ok", func(t *testing.T) {
+		testCtx := context.WithValue(ctx, config.KeyLogger, mockLogger)
+		testCtx = context.WithValue(testCtx, config.KeyUUID, "user123")
+
+		input := &advertproto.EditAdvertIn{
+			Id:          ID,
+			Title:       "title",
+			TextContent: "updated content",
+			UserFilter:  &advertproto.UserFilter{Os: []int64{22}},
+		}
+		advert := &model.AdvertLifecycle{ID: ID, OwnerUUID: "user123", Status: model.StatusActive}
 
-		s := New(mockRepo)
+		mockLogger.EXPECT().AddFuncName("EditAdvert").Times(1)
+		mockRepo.EXPECT().GetAttributeNames(testCtx, model.AttributeOs, []int64{22}).Return(model.AttributeNames{22: "Linux"}, nil)
+		mockRepo.EXPECT().EditAdvert(testCtx, gomock.Any(), gomock.Any()).DoAndReturn(editWith(advert, func(info *model.EditAdvert) {
+			assert.Equal(t, ID, info.ID)
+			assert.Equal(t, "updated content", info.TextContent)
+			assert.Equal(t, []int64{22}, info.UserFilter.Os)
+		}))
+
+		s := New(mockRepo, NewRolePolicy(), testValidator, testLifecycle)
 		result, err := s.EditAdvert(testCtx, input)
 
 		assert.NoError(t, err)
 		assert.Equal(t, &advertproto.AdvertEmpty{}, result)
 	})
 
-	t.Run("should_return_err_advert_active_check", func(t *testing.T) {
+	t.Run("should_update_masked_fields", func(t *testing.T) {
 		testCtx := context.WithValue(ctx, config.KeyLogger, mockLogger)
-		expectedErr := errors.New("db error")
+		testCtx = context.WithValue(testCtx, config.KeyUUID, "user123")
+
+		input := &advertproto.EditAdvertIn{
+			Id:          ID,
+			Title:       "new title",
+			TextContent: "not masked",
+			UpdateMask:  &fieldmaskpb.FieldMask{Paths: []string{"title"}},
+		}
+		advert := &model.AdvertLifecycle{ID: ID, OwnerUUID: "user123", Status: model.StatusActive}
+
+		mockLogger.EXPECT().AddFuncName("EditAdvert").Times(1)
+		mockRepo.EXPECT().EditAdvert(testCtx, gomock.Any(), gomock.Any()).DoAndReturn(editWith(advert, func(info *model.EditAdvert) {
+			assert.Equal(t, []string{model.EditFieldTitle}, info.Fields)
+			assert.Equal(t, "new title", info.Title)
+		}))
+
+		s := New(mockRepo, NewRolePolicy(), testValidator, testLifecycle)
+		_, err := s.EditAdvert(testCtx, input)
+		assert.NoError(t, err)
+	})
+
+	t.Run("should_update_expired_at", func(t *testing.T) {
+		testCtx := context.WithValue(ctx, config.KeyLogger, mockLogger)
+		testCtx = context.WithValue(testCtx, config.KeyUUID, "user123")
+
+		createdAt := time.Now().Add(-24 * time.Hour)
+		expiredAt := time.Now().Add(time.Hour).UTC()
+		input := &advertproto.EditAdvertIn{
+			Id:         ID,
+			ExpiredAt:  timestamppb.New(expiredAt),
+			UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"expired_at"}},
+		}
+		advert := &model.AdvertLifecycle{ID: ID, OwnerUUID: "user123", Status: model.StatusActive, CreatedAt: &createdAt}
+
+		mockLogger.EXPECT().AddFuncName("EditAdvert").Times(1)
+		mockRepo.EXPECT().EditAdvert(testCtx, gomock.Any(), gomock.Any()).DoAndReturn(editWith(advert, func(info *model.EditAdvert) {
+			assert.Equal(t, []string{model.EditFieldExpiredAt}, info.Fields)
+			assert.Equal(t, expiredAt, *info.ExpiredAt)
+		}))
 
-		input := &advertproto.EditAdvertIn{Id: ID}
+		s := New(mockRepo, NewRolePolicy(), testValidator, testLifecycle)
+		_, err := s.EditAdvert(testCtx, input)
+		assert.NoError(t, err)
+	})
+
+	t.Run("should_return_err_expired_at_over_lifetime", func(t *testing.T) {
+		testCtx := context.WithValue(ctx, config.KeyLogger, mockLogger)
+		testCtx = context.WithValue(testCtx, config.KeyUUID, "user123")
+
+		// лимит тот же, что у ExtendAdvert: считается от публикации, а не от текущего срока
+		createdAt := time.Now().Add(-20 * 24 * time.Hour)
+		input := &advertproto.EditAdvertIn{
+			Id:         ID,
+			ExpiredAt:  timestamppb.New(time.Now().Add(15 * 24 * time.Hour)),
+			UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"expired_at"}},
+		}
+		advert := &model.AdvertLifecycle{ID: ID, OwnerUUID: "user123", Status: model.StatusActive, CreatedAt: &createdAt}
 
 		mockLogger.EXPECT().AddFuncName("EditAdvert").Times(1)
-		mockRepo.EXPECT().IsAdvertActive(testCtx, int(ID)).Return(false, expectedErr)
-		mockLogger.EXPECT().Error(fmt.Sprintf("failed to check if the advert is active or not: %v", expectedErr))
+		mockRepo.EXPECT().EditAdvert(testCtx, gomock.Any(), gomock.Any()).DoAndReturn(editWith(advert, nil))
+		mockLogger.EXPECT().Error(gomock.Any())
 
-		s := New(mockRepo)
+		s := New(mockRepo, NewRolePolicy(), testValidator, testLifecycle)
 		_, err := s.EditAdvert(testCtx, input)
 
 		st, ok := status.FromError(err)
 		assert.True(t, ok)
-		assert.Equal(t, codes.Internal, st.Code())
-		assert.Contains(t, st.Message(), expectedErr.Error())
+		assert.Equal(t, codes.FailedPrecondition, st.Code())
+		assert.Contains(t, st.Message(), model.ErrMaxLifetimeExceeded.Error())
+	})
+
+	t.Run("should_return_err_start_at_after_publication", func(t *testing.T) {
+		testCtx := context.WithValue(ctx, config.KeyLogger, mockLogger)
+		testCtx = context.WithValue(testCtx, config.KeyUUID, "user123")
+
+		// иначе перенос start_at вперёд сдвигал бы начало отсчёта лимита срока жизни
+		createdAt := time.Now().Add(-24 * time.Hour)
+		input := &advertproto.EditAdvertIn{
+			Id:         ID,
+			StartAt:    timestamppb.New(time.Now().Add(24 * time.Hour)),
+			UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"start_at"}},
+		}
+		advert := &model.AdvertLifecycle{ID: ID, OwnerUUID: "user123", Status: model.StatusActive, CreatedAt: &createdAt}
+
+		mockLogger.EXPECT().AddFuncName("EditAdvert").Times(1)
+		mockRepo.EXPECT().EditAdvert(testCtx, gomock.Any(), gomock.Any()).DoAndReturn(editWith(advert, nil))
+		mockLogger.EXPECT().Error(gomock.Any())
+
+		s := New(mockRepo, NewRolePolicy(), testValidator, testLifecycle)
+		_, err := s.EditAdvert(testCtx, input)
+
+		st, ok := status.FromError(err)
+		assert.True(t, ok)
+		assert.Equal(t, codes.FailedPrecondition, st.Code())
+	})
+
+	t.Run("should_move_start_at_before_publication", func(t *testing.T) {
+		testCtx := context.WithValue(ctx, config.KeyLogger, mockLogger)
+		testCtx = context.WithValue(testCtx, config.KeyUUID, "user123")
+
+		createdAt := time.Now()
+		startAt := time.Now().Add(time.Hour)
+		newStartAt := time.Now().Add(10 * 24 * time.Hour).UTC()
+		input := &advertproto.EditAdvertIn{
+			Id:         ID,
+			StartAt:    timestamppb.New(newStartAt),
+			ExpiredAt:  timestamppb.New(newStartAt.Add(25 * 24 * time.Hour)),
+			UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"start_at", "expired_at"}},
+		}
+		advert := &model.AdvertLifecycle{ID: ID, OwnerUUID: "user123", Status: model.StatusActive, CreatedAt: &createdAt, StartAt: &startAt}
+
+		mockLogger.EXPECT().AddFuncName("EditAdvert").Times(1)
+		mockRepo.EXPECT().EditAdvert(testCtx, gomock.Any(), gomock.Any()).DoAndReturn(editWith(advert, func(info *model.EditAdvert) {
+			assert.Equal(t, newStartAt, *info.StartAt)
+		}))
+
+		s := New(mockRepo, NewRolePolicy(), testValidator, testLifecycle)
+		_, err := s.EditAdvert(testCtx, input)
+		assert.NoError(t, err)
+	})
+
+	t.Run("should_edit_scheduled_advert", func(t *testing.T) {
+		testCtx := context.WithValue(ctx, config.KeyLogger, mockLogger)
+		testCtx = context.WithValue(testCtx, config.KeyUUID, "user123")
+
+		startAt := time.Now().Add(time.Hour)
+		input := &advertproto.EditAdvertIn{Id: ID, Title: "title"}
+		advert := &model.AdvertLifecycle{ID: ID, OwnerUUID: "user123", Status: model.StatusActive, StartAt: &startAt}
+		advert.Status = advert.CurrentStatus(time.Now())
+
+		mockLogger.EXPECT().AddFuncName("EditAdvert").Times(1)
+		mockRepo.EXPECT().EditAdvert(testCtx, gomock.Any(), gomock.Any()).DoAndReturn(editWith(advert, nil))
+
+		s := New(mockRepo, NewRolePolicy(), testValidator, testLifecycle)
+		_, err := s.EditAdvert(testCtx, input)
+		assert.NoError(t, err)
+		assert.Equal(t, model.StatusScheduled, advert.Status)
+	})
+
+	t.Run("should_return_err_unknown_mask_field", func(t *testing.T) {
+		testCtx := context.WithValue(ctx, config.KeyLogger, mockLogger)
+		testCtx = context.WithValue(testCtx, config.KeyUUID, "user123")
+
+		input := &advertproto.EditAdvertIn{
+			Id:         ID,
+			UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"owner_uuid"}},
+		}
+
+		mockLogger.EXPECT().AddFuncName("EditAdvert").Times(1)
+		mockLogger.EXPECT().Error(gomock.Any())
+
+		s := New(mockRepo, NewRolePolicy(), testValidator, testLifecycle)
+		_, err := s.EditAdvert(testCtx, input)
+
+		st, ok := status.FromError(err)
+		assert.True(t, ok)
+		assert.Equal(t, codes.InvalidArgument, st.Code())
 	})
 
 	t.Run("should_return_err_advert_not_active", func(t *testing.T) {
 		testCtx := context.WithValue(ctx, config.KeyLogger, mockLogger)
+		testCtx = context.WithValue(testCtx, config.KeyUUID, "user123")
+
+		input := &advertproto.EditAdvertIn{Id: ID, Title: "title"}
+		advert := &model.AdvertLifecycle{ID: ID, OwnerUUID: "user123", Status: model.StatusCanceled}
+
+		mockLogger.EXPECT().AddFuncName("EditAdvert").Times(1)
+		mockRepo.EXPECT().EditAdvert(testCtx, gomock.Any(), gomock.Any()).DoAndReturn(editWith(advert, nil))
+		mockLogger.EXPECT().Error("failed to edit advert: advert is not active")
+
+		s := New(mockRepo, NewRolePolicy(), testValidator, testLifecycle)
+		_, err := s.EditAdvert(testCtx, input)
+
+		st, ok := status.FromError(err)
+		assert.True(t, ok)
+		assert.Equal(t, codes.FailedPrecondition, st.Code())
+		assert.Contains(t, st.Message(), "failed to edit advert: advert is not active")
+	})
+
+	t.Run("should_return_err_version_mismatch", func(t *testing.T) {
+		testCtx := context.WithValue(ctx, config.KeyLogger, mockLogger)
+		testCtx = context.WithValue(testCtx, config.KeyUUID, "user123")
 
-		input := &advertproto.EditAdvertIn{Id: ID}
+		input := &advertproto.EditAdvertIn{Id: ID, Title: "title", ExpectedVersion: 1}
+		advert := &model.AdvertLifecycle{ID: ID, OwnerUUID: "user123", Status: model.StatusActive, Version: 2}
 
 		mockLogger.EXPECT().AddFuncName("EditAdvert").Times(1)
-		mockRepo.EXPECT().IsAdvertActive(testCtx, int(ID)).Return(false, nil)
-		mockLogger.EXPECT().Error("failed to edit the advert, since it is not active")
+		mockRepo.EXPECT().EditAdvert(testCtx, gomock.Any(), gomock.Any()).DoAndReturn(editWith(advert, nil))
+		mockLogger.EXPECT().Error(gomock.Any())
 
-		s := New(mockRepo)
+		s := New(mockRepo, NewRolePolicy(), testValidator, testLifecycle)
 		_, err := s.EditAdvert(testCtx, input)
 
 		st, ok := status.FromError(err)
 		assert.True(t, ok)
-		assert.Equal(t, codes.Unavailable, st.Code())
-		assert.Contains(t, st.Message(), "failed to edit the advert, since it is not active")
+		assert.Equal(t, codes.Aborted, st.Code())
 	})
 
 	t.Run("should_return_err_missing_uuid", func(t *testing.T) {
 		testCtx := context.WithValue(ctx, config.KeyLogger, mockLogger)
 
-		input := &advertproto.EditAdvertIn{Id: ID}
+		input := &advertproto.EditAdvertIn{Id: ID, Title: "title"}
 
 		mockLogger.EXPECT().AddFuncName("EditAdvert").Times(1)
-		mockRepo.EXPECT().IsAdvertActive(testCtx, int(ID)).Return(true, nil)
 		mockLogger.EXPECT().Error("failed to find uuid")
 
-		s := New(mockRepo)
+		s := New(mockRepo, NewRolePolicy(), testValidator, testLifecycle)
 		_, err := s.EditAdvert(testCtx, input)
 
 		st, ok := status.FromError(err)
@@ -441,45 +1079,47 @@ func TestServer_EditAdvert(t *testing.T) {
 		assert.Contains(t, st.Message(), "failed to find uuid")
 	})
 
-	t.Run("should_return_err_get_owner_uuid", func(t *testing.T) {
+	t.Run("should_return_err_not_owner", func(t *testing.T) {
 		testCtx := context.WithValue(ctx, config.KeyLogger, mockLogger)
 		testCtx = context.WithValue(testCtx, config.KeyUUID, "user123")
-		expectedErr := errors.New("db error")
 
-		input := &advertproto.EditAdvertIn{Id: ID}
+		input := &advertproto.EditAdvertIn{Id: ID, Title: "title"}
+		advert := &model.AdvertLifecycle{ID: ID, OwnerUUID: "different_user", Status: model.StatusActive}
 
 		mockLogger.EXPECT().AddFuncName("EditAdvert").Times(1)
-		mockRepo.EXPECT().IsAdvertActive(testCtx, int(ID)).Return(true, nil)
-		mockRepo.EXPECT().GetOwnerUUID(testCtx, int(ID)).Return("", expectedErr)
-		mockLogger.EXPECT().Error(fmt.Sprintf("failed to get owner uuid: %v", expectedErr))
+		mockRepo.EXPECT().EditAdvert(testCtx, gomock.Any(), gomock.Any()).DoAndReturn(editWith(advert, nil))
+		mockLogger.EXPECT().Error(gomock.Any())
 
-		s := New(mockRepo)
+		s := New(mockRepo, NewRolePolicy(), testValidator, testLifecycle)
 		_, err := s.EditAdvert(testCtx, input)
 
 		st, ok := status.FromError(err)
 		assert.True(t, ok)
-		assert.Equal(t, codes.Internal, st.Code())
-		assert.Contains(t, st.Message(), expectedErr.Error())
+		assert.Equal(t, codes.PermissionDenied, st.Code())
+		assert.Contains(t, st.Message(), model.ErrPermissionDenied.Error())
 	})
 
-	t.Run("should_return_err_not_owner", func(t *testing.T) {
+	t.Run("should_reject_unknown_os", func(t *testing.T) {
 		testCtx := context.WithValue(ctx, config.KeyLogger, mockLogger)
 		testCtx = context.WithValue(testCtx, config.KeyUUID, "user123")
 
-		input := &advertproto.EditAdvertIn{Id: ID}
+		input := &advertproto.EditAdvertIn{
+			Id:         ID,
+			UserFilter: &advertproto.UserFilter{Os: []int64{22}},
+			UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"user_filter"}},
+		}
 
 		mockLogger.EXPECT().AddFuncName("EditAdvert").Times(1)
-		mockRepo.EXPECT().IsAdvertActive(testCtx, int(ID)).Return(true, nil)
-		mockRepo.EXPECT().GetOwnerUUID(testCtx, int(ID)).Return("different_user", nil)
-		mockLogger.EXPECT().Error("failed to edit: user is not owner")
+		mockRepo.EXPECT().GetAttributeNames(testCtx, model.AttributeOs, []int64{22}).Return(model.AttributeNames{}, nil)
+		mockLogger.EXPECT().Error(gomock.Any())
 
-		s := New(mockRepo)
+		s := New(mockRepo, NewRolePolicy(), testValidator, testLifecycle)
 		_, err := s.EditAdvert(testCtx, input)
 
 		st, ok := status.FromError(err)
 		assert.True(t, ok)
-		assert.Equal(t, codes.PermissionDenied, st.Code())
-		assert.Contains(t, st.Message(), "failed to edit: user is not owner")
+		assert.Equal(t, codes.InvalidArgument, st.Code())
+		assert.Contains(t, st.Message(), "user_filter.os: unknown os id 22")
 	})
 
 	t.Run("should_return_err_edit_advert", func(t *testing.T) {
@@ -489,17 +1129,17 @@ func TestServer_EditAdvert(t *testing.T) {
 
 		input := &advertproto.EditAdvertIn{
 			Id:          ID,
+			Title:       "title",
 			TextContent: "updated content",
 			UserFilter:  &advertproto.UserFilter{Os: []int64{22}},
 		}
 
 		mockLogger.EXPECT().AddFuncName("EditAdvert").Times(1)
-		mockRepo.EXPECT().IsAdvertActive(testCtx, int(ID)).Return(true, nil)
-		mockRepo.EXPECT().GetOwnerUUID(testCtx, int(ID)).Return("user123", nil)
-		mockRepo.EXPECT().EditAdvert(testCtx, gomock.Any()).Return(expectedErr)
+		mockRepo.EXPECT().GetAttributeNames(testCtx, model.AttributeOs, []int64{22}).Return(model.AttributeNames{22: "Linux"}, nil)
+		mockRepo.EXPECT().EditAdvert(testCtx, gomock.Any(), gomock.Any()).Return(expectedErr)
 		mockLogger.EXPECT().Error(fmt.Sprintf("failed to edit advert: %v", expectedErr))
 
-		s := New(mockRepo)
+		s := New(mockRepo, NewRolePolicy(), testValidator, testLifecycle)
 		_, err := s.EditAdvert(testCtx, input)
 
 		st, ok := status.FromError(err)
@@ -508,3 +1148,741 @@ func TestServer_EditAdvert(t *testing.T) {
 		assert.Contains(t, st.Message(), expectedErr.Error())
 	})
 }
+
+func TestService_BanAdvert(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	ID := int64(123)
+	moderatorUUID := "moderator-uuid"
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockRepo := NewMockDBRepo(ctrl)
+
+	mockLogger := logger_lib.NewMockLoggerInterface(ctrl)
+
+	t.Run("ban_ok", func(t *testing.T) {
+		testCtx := context.WithValue(ctx, config.KeyLogger, mockLogger)
+		testCtx = context.WithValue(testCtx, config.KeyUUID, moderatorUUID)
+		testCtx = context.WithValue(testCtx, config.KeyRole, model.RoleModerator)
+		advert := &model.AdvertLifecycle{ID: ID, Status: model.StatusActive}
+
+		mockLogger.EXPECT().AddFuncName("BanAdvert")
+		mockRepo.EXPECT().TransitAdvert(testCtx, ID, gomock.Any()).DoAndReturn(transitWith(advert, func(transition *model.Transition) {
+			assert.Equal(t, model.StatusBanned, transition.To)
+			assert.Equal(t, "спам", transition.BanReason)
+			assert.Equal(t, moderatorUUID, transition.BannedBy)
+		}))
+
+		s := New(mockRepo, NewRolePolicy(), testValidator, testLifecycle)
+		result, err := s.BanAdvert(testCtx, &advertproto.BanAdvertIn{Id: ID, Reason: "спам"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, &advertproto.AdvertEmpty{}, result)
+	})
+
+	t.Run("ban_by_service_account", func(t *testing.T) {
+		// сервисный аккаунт из mTLS приходит со своим uuid, который и пишется в banned_by
+		serviceUUID := "0b9f6d3a-2c1e-4f5a-8b7c-9d0e1f2a3b4c"
+		testCtx := context.WithValue(ctx, config.KeyLogger, mockLogger)
+		testCtx = context.WithValue(testCtx, config.KeyUUID, serviceUUID)
+		testCtx = context.WithValue(testCtx, config.KeyRole, model.RoleService)
+		advert := &model.AdvertLifecycle{ID: ID, Status: model.StatusActive}
+
+		mockLogger.EXPECT().AddFuncName("BanAdvert")
+		mockRepo.EXPECT().TransitAdvert(testCtx, ID, gomock.Any()).DoAndReturn(transitWith(advert, func(transition *model.Transition) {
+			assert.Equal(t, model.StatusBanned, transition.To)
+			assert.Equal(t, serviceUUID, transition.BannedBy)
+		}))
+
+		s := New(mockRepo, NewRolePolicy(), testValidator, testLifecycle)
+		_, err := s.BanAdvert(testCtx, &advertproto.BanAdvertIn{Id: ID, Reason: "спам"})
+		assert.NoError(t, err)
+	})
+
+	t.Run("ban_no_uuid", func(t *testing.T) {
+		testCtx := context.WithValue(ctx, config.KeyLogger, mockLogger)
+
+		mockLogger.EXPECT().AddFuncName("BanAdvert")
+		mockLogger.EXPECT().Error("failed to find uuid")
+
+		s := New(mockRepo, NewRolePolicy(), testValidator, testLifecycle)
+		_, err := s.BanAdvert(testCtx, &advertproto.BanAdvertIn{Id: ID, Reason: "спам"})
+
+		st, ok := status.FromError(err)
+		assert.True(t, ok)
+		assert.Equal(t, codes.Unauthenticated, st.Code())
+		assert.Contains(t, st.Message(), "failed to find uuid")
+	})
+
+	t.Run("ban_not_moderator", func(t *testing.T) {
+		testCtx := context.WithValue(ctx, config.KeyLogger, mockLogger)
+		testCtx = context.WithValue(testCtx, config.KeyUUID, "user-uuid")
+
+		mockLogger.EXPECT().AddFuncName("BanAdvert")
+		mockLogger.EXPECT().Error(gomock.Any())
+
+		s := New(mockRepo, NewRolePolicy(), testValidator, testLifecycle)
+		_, err := s.BanAdvert(testCtx, &advertproto.BanAdvertIn{Id: ID, Reason: "спам"})
+
+		st, ok := status.FromError(err)
+		assert.True(t, ok)
+		assert.Equal(t, codes.PermissionDenied, st.Code())
+		assert.Contains(t, st.Message(), model.ErrPermissionDenied.Error())
+	})
+
+	t.Run("ban_empty_reason", func(t *testing.T) {
+		testCtx := context.WithValue(ctx, config.KeyLogger, mockLogger)
+		testCtx = context.WithValue(testCtx, config.KeyUUID, moderatorUUID)
+		testCtx = context.WithValue(testCtx, config.KeyRole, model.RoleModerator)
+
+		mockLogger.EXPECT().AddFuncName("BanAdvert")
+		mockLogger.EXPECT().Error("failed to ban: reason is empty")
+
+		s := New(mockRepo, NewRolePolicy(), testValidator, testLifecycle)
+		_, err := s.BanAdvert(testCtx, &advertproto.BanAdvertIn{Id: ID})
+
+		st, ok := status.FromError(err)
+		assert.True(t, ok)
+		assert.Equal(t, codes.InvalidArgument, st.Code())
+		assert.Contains(t, st.Message(), "failed to ban: reason is empty")
+	})
+
+	t.Run("ban_already_banned", func(t *testing.T) {
+		testCtx := context.WithValue(ctx, config.KeyLogger, mockLogger)
+		testCtx = context.WithValue(testCtx, config.KeyUUID, moderatorUUID)
+		testCtx = context.WithValue(testCtx, config.KeyRole, model.RoleModerator)
+		advert := &model.AdvertLifecycle{ID: ID, Status: model.StatusBanned}
+
+		mockLogger.EXPECT().AddFuncName("BanAdvert")
+		mockRepo.EXPECT().TransitAdvert(testCtx, ID, gomock.Any()).DoAndReturn(transitWith(advert, nil))
+		mockLogger.EXPECT().Error(gomock.Any())
+
+		s := New(mockRepo, NewRolePolicy(), testValidator, testLifecycle)
+		_, err := s.BanAdvert(testCtx, &advertproto.BanAdvertIn{Id: ID, Reason: "спам"})
+
+		st, ok := status.FromError(err)
+		assert.True(t, ok)
+		assert.Equal(t, codes.FailedPrecondition, st.Code())
+	})
+
+	t.Run("ban_err", func(t *testing.T) {
+		testCtx := context.WithValue(ctx, config.KeyLogger, mockLogger)
+		testCtx = context.WithValue(testCtx, config.KeyUUID, moderatorUUID)
+		testCtx = context.WithValue(testCtx, config.KeyRole, model.RoleModerator)
+		expectedErr := errors.New("db error")
+
+		mockLogger.EXPECT().AddFuncName("BanAdvert")
+		mockRepo.EXPECT().TransitAdvert(testCtx, ID, gomock.Any()).Return(expectedErr)
+		mockLogger.EXPECT().Error(fmt.Sprintf("failed to ban advert: %v", expectedErr))
+
+		s := New(mockRepo, NewRolePolicy(), testValidator, testLifecycle)
+		_, err := s.BanAdvert(testCtx, &advertproto.BanAdvertIn{Id: ID, Reason: "спам"})
+
+		st, ok := status.FromError(err)
+		assert.True(t, ok)
+		assert.Equal(t, codes.Internal, st.Code())
+		assert.Contains(t, st.Message(), expectedErr.Error())
+	})
+}
+
+func TestService_UnbanAdvert(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	ID := int64(123)
+	ctx = context.WithValue(ctx, config.KeyUUID, "moderator-uuid")
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockRepo := NewMockDBRepo(ctrl)
+
+	mockLogger := logger_lib.NewMockLoggerInterface(ctrl)
+
+	t.Run("unban_ok", func(t *testing.T) {
+		testCtx := context.WithValue(ctx, config.KeyLogger, mockLogger)
+		testCtx = context.WithValue(testCtx, config.KeyRole, model.RoleModerator)
+		advert := &model.AdvertLifecycle{ID: ID, Status: model.StatusBanned}
+
+		mockLogger.EXPECT().AddFuncName("UnbanAdvert")
+		mockRepo.EXPECT().TransitAdvert(testCtx, ID, gomock.Any()).DoAndReturn(transitWith(advert, func(transition *model.Transition) {
+			assert.Equal(t, model.StatusActive, transition.To)
+		}))
+
+		s := New(mockRepo, NewRolePolicy(), testValidator, testLifecycle)
+		result, err := s.UnbanAdvert(testCtx, &advertproto.UnbanAdvertIn{Id: ID})
+
+		assert.NoError(t, err)
+		assert.Equal(t, &advertproto.AdvertEmpty{}, result)
+	})
+
+	t.Run("unban_not_moderator", func(t *testing.T) {
+		testCtx := context.WithValue(ctx, config.KeyLogger, mockLogger)
+
+		mockLogger.EXPECT().AddFuncName("UnbanAdvert")
+		mockLogger.EXPECT().Error(gomock.Any())
+
+		s := New(mockRepo, NewRolePolicy(), testValidator, testLifecycle)
+		_, err := s.UnbanAdvert(testCtx, &advertproto.UnbanAdvertIn{Id: ID})
+
+		st, ok := status.FromError(err)
+		assert.True(t, ok)
+		assert.Equal(t, codes.PermissionDenied, st.Code())
+	})
+
+	t.Run("unban_not_banned", func(t *testing.T) {
+		testCtx := context.WithValue(ctx, config.KeyLogger, mockLogger)
+		testCtx = context.WithValue(testCtx, config.KeyRole, model.RoleModerator)
+		advert := &model.AdvertLifecycle{ID: ID, Status: model.StatusActive}
+
+		mockLogger.EXPECT().AddFuncName("UnbanAdvert")
+		mockRepo.EXPECT().TransitAdvert(testCtx, ID, gomock.Any()).DoAndReturn(transitWith(advert, nil))
+		mockLogger.EXPECT().Error(fmt.Sprintf("failed to unban advert: %v", model.ErrAdvertNotBanned))
+
+		s := New(mockRepo, NewRolePolicy(), testValidator, testLifecycle)
+		_, err := s.UnbanAdvert(testCtx, &advertproto.UnbanAdvertIn{Id: ID})
+
+		st, ok := status.FromError(err)
+		assert.True(t, ok)
+		assert.Equal(t, codes.FailedPrecondition, st.Code())
+	})
+
+	t.Run("unban_no_uuid", func(t *testing.T) {
+		testCtx := context.WithValue(context.Background(), config.KeyLogger, mockLogger)
+
+		mockLogger.EXPECT().AddFuncName("UnbanAdvert")
+		mockLogger.EXPECT().Error("failed to find uuid")
+
+		s := New(mockRepo, NewRolePolicy(), testValidator, testLifecycle)
+		_, err := s.UnbanAdvert(testCtx, &advertproto.UnbanAdvertIn{Id: ID})
+
+		st, ok := status.FromError(err)
+		assert.True(t, ok)
+		assert.Equal(t, codes.Unauthenticated, st.Code())
+	})
+
+	t.Run("unban_err", func(t *testing.T) {
+		testCtx := context.WithValue(ctx, config.KeyLogger, mockLogger)
+		testCtx = context.WithValue(testCtx, config.KeyRole, model.RoleModerator)
+		expectedErr := errors.New("db error")
+
+		mockLogger.EXPECT().AddFuncName("UnbanAdvert")
+		mockRepo.EXPECT().TransitAdvert(testCtx, ID, gomock.Any()).Return(expectedErr)
+		mockLogger.EXPECT().Error(fmt.Sprintf("failed to unban advert: %v", expectedErr))
+
+		s := New(mockRepo, NewRolePolicy(), testValidator, testLifecycle)
+		_, err := s.UnbanAdvert(testCtx, &advertproto.UnbanAdvertIn{Id: ID})
+
+		st, ok := status.FromError(err)
+		assert.True(t, ok)
+		assert.Equal(t, codes.Internal, st.Code())
+		assert.Contains(t, st.Message(), expectedErr.Error())
+	})
+}
+
+func TestService_ListAdvertRevisions(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	uuid := "test-uuid"
+	ID := int64(123)
+	ctx = context.WithValue(ctx, config.KeyUUID, uuid)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockRepo := NewMockDBRepo(ctrl)
+	mockLogger := logger_lib.NewMockLoggerInterface(ctrl)
+	ctx = context.WithValue(ctx, config.KeyLogger, mockLogger)
+
+	t.Run("list_ok", func(t *testing.T) {
+		revisions := model.AdvertRevisionList{
+			{ID: 2, AdvertID: ID, Version: 3, Title: "новый", EditedBy: uuid},
+			{ID: 1, AdvertID: ID, Version: 1, Title: "старый", EditedBy: uuid},
+		}
+
+		mockLogger.EXPECT().AddFuncName("ListAdvertRevisions")
+		mockRepo.EXPECT().GetAdvert(ctx, &advertproto.GetAdvertIn{Id: ID}).Return(&model.AdvertInfo{ID: ID, OwnerUUID: uuid}, nil)
+		mockRepo.EXPECT().GetAdvertRevisions(ctx, ID).Return(&revisions, nil)
+
+		s := New(mockRepo, NewRolePolicy(), testValidator, testLifecycle)
+		out, err := s.ListAdvertRevisions(ctx, &advertproto.ListAdvertRevisionsIn{AdvertId: ID})
+		assert.NoError(t, err)
+		assert.Equal(t, revisions.ListFromDTO(), out.Revisions)
+	})
+
+	t.Run("list_moderator_ok", func(t *testing.T) {
+		testCtx := context.WithValue(ctx, config.KeyRole, model.RoleModerator)
+		revisions := model.AdvertRevisionList{}
+
+		mockLogger.EXPECT().AddFuncName("ListAdvertRevisions")
+		mockRepo.EXPECT().GetAdvert(testCtx, gomock.Any()).Return(&model.AdvertInfo{ID: ID, OwnerUUID: "other-uuid"}, nil)
+		mockRepo.EXPECT().GetAdvertRevisions(testCtx, ID).Return(&revisions, nil)
+
+		s := New(mockRepo, NewRolePolicy(), testValidator, testLifecycle)
+		_, err := s.ListAdvertRevisions(testCtx, &advertproto.ListAdvertRevisionsIn{AdvertId: ID})
+		assert.NoError(t, err)
+	})
+
+	t.Run("list_not_owner", func(t *testing.T) {
+		mockLogger.EXPECT().AddFuncName("ListAdvertRevisions")
+		mockRepo.EXPECT().GetAdvert(ctx, gomock.Any()).Return(&model.AdvertInfo{ID: ID, OwnerUUID: "other-uuid"}, nil)
+		mockLogger.EXPECT().Error(gomock.Any())
+
+		s := New(mockRepo, NewRolePolicy(), testValidator, testLifecycle)
+		_, err := s.ListAdvertRevisions(ctx, &advertproto.ListAdvertRevisionsIn{AdvertId: ID})
+
+		st, ok := status.FromError(err)
+		assert.True(t, ok)
+		assert.Equal(t, codes.PermissionDenied, st.Code())
+	})
+
+	t.Run("list_advert_not_found", func(t *testing.T) {
+		mockLogger.EXPECT().AddFuncName("ListAdvertRevisions")
+		mockRepo.EXPECT().GetAdvert(ctx, gomock.Any()).Return(nil, postgres.ErrNotFound)
+		mockLogger.EXPECT().Error(gomock.Any())
+
+		s := New(mockRepo, NewRolePolicy(), testValidator, testLifecycle)
+		_, err := s.ListAdvertRevisions(ctx, &advertproto.ListAdvertRevisionsIn{AdvertId: ID})
+
+		st, ok := status.FromError(err)
+		assert.True(t, ok)
+		assert.Equal(t, codes.NotFound, st.Code())
+	})
+
+	t.Run("list_err", func(t *testing.T) {
+		expectedErr := errors.New("db error")
+
+		mockLogger.EXPECT().AddFuncName("ListAdvertRevisions")
+		mockRepo.EXPECT().GetAdvert(ctx, gomock.Any()).Return(&model.AdvertInfo{ID: ID, OwnerUUID: uuid}, nil)
+		mockRepo.EXPECT().GetAdvertRevisions(ctx, ID).Return(nil, expectedErr)
+		mockLogger.EXPECT().Error(fmt.Sprintf("failed to list advert revisions: %v", expectedErr))
+
+		s := New(mockRepo, NewRolePolicy(), testValidator, testLifecycle)
+		_, err := s.ListAdvertRevisions(ctx, &advertproto.ListAdvertRevisionsIn{AdvertId: ID})
+
+		st, ok := status.FromError(err)
+		assert.True(t, ok)
+		assert.Equal(t, codes.Internal, st.Code())
+	})
+
+	t.Run("list_no_uuid", func(t *testing.T) {
+		testCtx := context.WithValue(context.Background(), config.KeyLogger, mockLogger)
+
+		mockLogger.EXPECT().AddFuncName("ListAdvertRevisions")
+		mockLogger.EXPECT().Error("failed to find uuid")
+
+		s := New(mockRepo, NewRolePolicy(), testValidator, testLifecycle)
+		_, err := s.ListAdvertRevisions(testCtx, &advertproto.ListAdvertRevisionsIn{AdvertId: ID})
+
+		st, ok := status.FromError(err)
+		assert.True(t, ok)
+		assert.Equal(t, codes.Unauthenticated, st.Code())
+	})
+}
+
+func TestService_RevertAdvert(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	uuid := "test-uuid"
+	ID := int64(123)
+	revisionID := int64(7)
+	ctx = context.WithValue(ctx, config.KeyUUID, uuid)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockRepo := NewMockDBRepo(ctrl)
+	mockLogger := logger_lib.NewMockLoggerInterface(ctrl)
+	ctx = context.WithValue(ctx, config.KeyLogger, mockLogger)
+
+	revision := &model.AdvertRevision{
+		ID:          revisionID,
+		AdvertID:    ID,
+		Version:     1,
+		Title:       "старый",
+		TextContent: "старый текст",
+		UserFilter:  model.UserFilter{Os: []int64{1}},
+	}
+
+	t.Run("revert_ok", func(t *testing.T) {
+		advert := &model.AdvertLifecycle{ID: ID, OwnerUUID: uuid, Status: model.StatusActive, Version: 4}
+
+		mockLogger.EXPECT().AddFuncName("RevertAdvert")
+		mockRepo.EXPECT().GetAdvert(ctx, &advertproto.GetAdvertIn{Id: ID}).Return(&model.AdvertInfo{ID: ID, OwnerUUID: uuid}, nil)
+		mockRepo.EXPECT().GetAdvertRevision(ctx, ID, revisionID).Return(revision, nil)
+		mockRepo.EXPECT().EditAdvert(ctx, gomock.Any(), gomock.Any()).DoAndReturn(editWith(advert, func(info *model.EditAdvert) {
+			assert.Equal(t, ID, info.ID)
+			assert.Equal(t, "старый", info.Title)
+			assert.Equal(t, []int64{1}, info.UserFilter.Os)
+			assert.Equal(t, uuid, info.EditedBy)
+			assert.NotContains(t, info.Fields, model.EditFieldExpiredAt)
+		}))
+
+		s := New(mockRepo, NewRolePolicy(), testValidator, testLifecycle)
+		_, err := s.RevertAdvert(ctx, &advertproto.RevertAdvertIn{AdvertId: ID, RevisionId: revisionID, ExpectedVersion: 4})
+		assert.NoError(t, err)
+	})
+
+	t.Run("revert_revision_not_found", func(t *testing.T) {
+		expectedErr := fmt.Errorf("failed to get advert revision from db: %w", postgres.ErrNotFound)
+
+		mockLogger.EXPECT().AddFuncName("RevertAdvert")
+		mockRepo.EXPECT().GetAdvert(ctx, &advertproto.GetAdvertIn{Id: ID}).Return(&model.AdvertInfo{ID: ID, OwnerUUID: uuid}, nil)
+		mockRepo.EXPECT().GetAdvertRevision(ctx, ID, revisionID).Return(nil, expectedErr)
+		mockLogger.EXPECT().Error(fmt.Sprintf("failed to get advert revision: %v", expectedErr))
+
+		s := New(mockRepo, NewRolePolicy(), testValidator, testLifecycle)
+		_, err := s.RevertAdvert(ctx, &advertproto.RevertAdvertIn{AdvertId: ID, RevisionId: revisionID})
+
+		st, ok := status.FromError(err)
+		assert.True(t, ok)
+		assert.Equal(t, codes.NotFound, st.Code())
+	})
+
+	t.Run("revert_not_owner", func(t *testing.T) {
+		mockLogger.EXPECT().AddFuncName("RevertAdvert")
+		mockRepo.EXPECT().GetAdvert(ctx, &advertproto.GetAdvertIn{Id: ID}).Return(&model.AdvertInfo{ID: ID, OwnerUUID: "other-uuid"}, nil)
+		mockLogger.EXPECT().Error(gomock.Any())
+
+		s := New(mockRepo, NewRolePolicy(), testValidator, testLifecycle)
+		_, err := s.RevertAdvert(ctx, &advertproto.RevertAdvertIn{AdvertId: ID, RevisionId: revisionID})
+
+		st, ok := status.FromError(err)
+		assert.True(t, ok)
+		assert.Equal(t, codes.PermissionDenied, st.Code())
+	})
+
+	t.Run("revert_no_uuid", func(t *testing.T) {
+		testCtx := context.WithValue(context.Background(), config.KeyLogger, mockLogger)
+
+		mockLogger.EXPECT().AddFuncName("RevertAdvert")
+		mockLogger.EXPECT().Error("failed to find uuid")
+
+		s := New(mockRepo, NewRolePolicy(), testValidator, testLifecycle)
+		_, err := s.RevertAdvert(testCtx, &advertproto.RevertAdvertIn{AdvertId: ID, RevisionId: revisionID})
+
+		st, ok := status.FromError(err)
+		assert.True(t, ok)
+		assert.Equal(t, codes.Unauthenticated, st.Code())
+	})
+}
+
+func TestService_PauseAdvert(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	uuid := "test-uuid"
+	ID := int64(123)
+	ctx = context.WithValue(ctx, config.KeyUUID, uuid)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockRepo := NewMockDBRepo(ctrl)
+	mockLogger := logger_lib.NewMockLoggerInterface(ctrl)
+	ctx = context.WithValue(ctx, config.KeyLogger, mockLogger)
+
+	t.Run("pause_ok", func(t *testing.T) {
+		advert := &model.AdvertLifecycle{ID: ID, OwnerUUID: uuid, Status: model.StatusActive}
+
+		mockLogger.EXPECT().AddFuncName("PauseAdvert")
+		mockRepo.EXPECT().TransitAdvert(ctx, ID, gomock.Any()).DoAndReturn(transitWith(advert, func(transition *model.Transition) {
+			assert.Equal(t, model.StatusPaused, transition.To)
+			assert.Nil(t, transition.ExpiredAt)
+		}))
+
+		s := New(mockRepo, NewRolePolicy(), testValidator, testLifecycle)
+		_, err := s.PauseAdvert(ctx, &advertproto.PauseAdvertIn{Id: ID})
+		assert.NoError(t, err)
+	})
+
+	t.Run("pause_not_owner", func(t *testing.T) {
+		advert := &model.AdvertLifecycle{ID: ID, OwnerUUID: "other-uuid", Status: model.StatusActive}
+
+		mockLogger.EXPECT().AddFuncName("PauseAdvert")
+		mockRepo.EXPECT().TransitAdvert(ctx, ID, gomock.Any()).DoAndReturn(transitWith(advert, nil))
+		mockLogger.EXPECT().Error(gomock.Any())
+
+		s := New(mockRepo, NewRolePolicy(), testValidator, testLifecycle)
+		_, err := s.PauseAdvert(ctx, &advertproto.PauseAdvertIn{Id: ID})
+
+		st, ok := status.FromError(err)
+		assert.True(t, ok)
+		assert.Equal(t, codes.PermissionDenied, st.Code())
+	})
+
+	t.Run("pause_version_mismatch", func(t *testing.T) {
+		advert := &model.AdvertLifecycle{ID: ID, OwnerUUID: uuid, Status: model.StatusActive, Version: 2}
+
+		mockLogger.EXPECT().AddFuncName("PauseAdvert")
+		mockRepo.EXPECT().TransitAdvert(ctx, ID, gomock.Any()).DoAndReturn(transitWith(advert, nil))
+		mockLogger.EXPECT().Error(gomock.Any())
+
+		s := New(mockRepo, NewRolePolicy(), testValidator, testLifecycle)
+		_, err := s.PauseAdvert(ctx, &advertproto.PauseAdvertIn{Id: ID, ExpectedVersion: 1})
+
+		st, ok := status.FromError(err)
+		assert.True(t, ok)
+		assert.Equal(t, codes.Aborted, st.Code())
+	})
+
+	t.Run("pause_no_uuid", func(t *testing.T) {
+		testCtx := context.WithValue(context.Background(), config.KeyLogger, mockLogger)
+
+		mockLogger.EXPECT().AddFuncName("PauseAdvert")
+		mockLogger.EXPECT().Error("failed to find uuid")
+
+		s := New(mockRepo, NewRolePolicy(), testValidator, testLifecycle)
+		_, err := s.PauseAdvert(testCtx, &advertproto.PauseAdvertIn{Id: ID})
+
+		st, ok := status.FromError(err)
+		assert.True(t, ok)
+		assert.Equal(t, codes.Unauthenticated, st.Code())
+	})
+}
+
+func TestService_ResumeAdvert(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	uuid := "test-uuid"
+	ID := int64(123)
+	ctx = context.WithValue(ctx, config.KeyUUID, uuid)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockRepo := NewMockDBRepo(ctrl)
+	mockLogger := logger_lib.NewMockLoggerInterface(ctrl)
+	ctx = context.WithValue(ctx, config.KeyLogger, mockLogger)
+
+	pausedAt := time.Now().Add(-2 * time.Hour)
+	expiredAt := time.Now().Add(time.Hour)
+
+	t.Run("resume_extends_expiry", func(t *testing.T) {
+		advert := &model.AdvertLifecycle{ID: ID, OwnerUUID: uuid, Status: model.StatusPaused, PausedAt: &pausedAt, ExpiredAt: &expiredAt}
+
+		mockLogger.EXPECT().AddFuncName("ResumeAdvert")
+		mockRepo.EXPECT().TransitAdvert(ctx, ID, gomock.Any()).DoAndReturn(transitWith(advert, func(transition *model.Transition) {
+			assert.Equal(t, model.StatusActive, transition.To)
+			assert.NotNil(t, transition.ExpiredAt)
+			assert.True(t, transition.ExpiredAt.After(expiredAt.Add(119*time.Minute)))
+		}))
+
+		s := New(mockRepo, NewRolePolicy(), testValidator, testLifecycle)
+		_, err := s.ResumeAdvert(ctx, &advertproto.ResumeAdvertIn{Id: ID})
+		assert.NoError(t, err)
+	})
+
+	t.Run("resume_keeps_expiry", func(t *testing.T) {
+		advert := &model.AdvertLifecycle{ID: ID, OwnerUUID: uuid, Status: model.StatusPaused, PausedAt: &pausedAt, ExpiredAt: &expiredAt}
+
+		mockLogger.EXPECT().AddFuncName("ResumeAdvert")
+		mockRepo.EXPECT().TransitAdvert(ctx, ID, gomock.Any()).DoAndReturn(transitWith(advert, func(transition *model.Transition) {
+			assert.Equal(t, model.StatusActive, transition.To)
+			assert.Nil(t, transition.ExpiredAt)
+		}))
+
+		lifecycle := testLifecycle
+		lifecycle.PauseExtendsExpiry = false
+		s := New(mockRepo, NewRolePolicy(), testValidator, lifecycle)
+		_, err := s.ResumeAdvert(ctx, &advertproto.ResumeAdvertIn{Id: ID})
+		assert.NoError(t, err)
+	})
+
+	t.Run("resume_not_paused", func(t *testing.T) {
+		advert := &model.AdvertLifecycle{ID: ID, OwnerUUID: uuid, Status: model.StatusActive}
+
+		mockLogger.EXPECT().AddFuncName("ResumeAdvert")
+		mockRepo.EXPECT().TransitAdvert(ctx, ID, gomock.Any()).DoAndReturn(transitWith(advert, nil))
+		mockLogger.EXPECT().Error("failed to resume advert: advert is not paused")
+
+		s := New(mockRepo, NewRolePolicy(), testValidator, testLifecycle)
+		_, err := s.ResumeAdvert(ctx, &advertproto.ResumeAdvertIn{Id: ID})
+
+		st, ok := status.FromError(err)
+		assert.True(t, ok)
+		assert.Equal(t, codes.FailedPrecondition, st.Code())
+	})
+
+	t.Run("resume_no_uuid", func(t *testing.T) {
+		testCtx := context.WithValue(context.Background(), config.KeyLogger, mockLogger)
+
+		mockLogger.EXPECT().AddFuncName("ResumeAdvert")
+		mockLogger.EXPECT().Error("failed to find uuid")
+
+		s := New(mockRepo, NewRolePolicy(), testValidator, testLifecycle)
+		_, err := s.ResumeAdvert(testCtx, &advertproto.ResumeAdvertIn{Id: ID})
+
+		st, ok := status.FromError(err)
+		assert.True(t, ok)
+		assert.Equal(t, codes.Unauthenticated, st.Code())
+	})
+}
+
+// extendWith эмулирует ExtendAdvert репозитория над заданным состоянием объявления
+func extendWith(advert *model.AdvertLifecycle, check func(*model.Extension)) func(context.Context, int64, string, model.ExtendFunc) error {
+	return func(_ context.Context, _ int64, _ string, fn model.ExtendFunc) error {
+		extension, err := fn(advert)
+		if err != nil {
+			return err
+		}
+		if check != nil {
+			check(extension)
+		}
+		return nil
+	}
+}
+
+func TestService_ExtendAdvert(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	uuid := "test-uuid"
+	ID := int64(123)
+	ctx = context.WithValue(ctx, config.KeyUUID, uuid)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockRepo := NewMockDBRepo(ctrl)
+	mockLogger := logger_lib.NewMockLoggerInterface(ctrl)
+	ctx = context.WithValue(ctx, config.KeyLogger, mockLogger)
+
+	createdAt := time.Now().Add(-24 * time.Hour)
+
+	t.Run("extend_by_duration", func(t *testing.T) {
+		expiredAt := time.Now().Add(24 * time.Hour)
+		advert := &model.AdvertLifecycle{ID: ID, OwnerUUID: uuid, Status: model.StatusActive, ExpiredAt: &expiredAt, CreatedAt: &createdAt}
+
+		mockLogger.EXPECT().AddFuncName("ExtendAdvert")
+		mockRepo.EXPECT().ExtendAdvert(ctx, ID, uuid, gomock.Any()).DoAndReturn(extendWith(advert, func(ext *model.Extension) {
+			assert.False(t, ext.Renew)
+			assert.Equal(t, expiredAt.Add(48*time.Hour), ext.ExpiredAt)
+		}))
+
+		s := New(mockRepo, NewRolePolicy(), testValidator, testLifecycle)
+		_, err := s.ExtendAdvert(ctx, &advertproto.ExtendAdvertIn{Id: ID, Duration: durationpb.New(48 * time.Hour)})
+		assert.NoError(t, err)
+	})
+
+	t.Run("renew_expired", func(t *testing.T) {
+		expiredAt := time.Now().Add(-time.Hour)
+		advert := &model.AdvertLifecycle{ID: ID, OwnerUUID: uuid, Status: model.StatusExpired, ExpiredAt: &expiredAt, CreatedAt: &createdAt}
+		newExpiredAt := time.Now().Add(72 * time.Hour).UTC()
+
+		mockLogger.EXPECT().AddFuncName("ExtendAdvert")
+		mockRepo.EXPECT().ExtendAdvert(ctx, ID, uuid, gomock.Any()).DoAndReturn(extendWith(advert, func(ext *model.Extension) {
+			assert.True(t, ext.Renew)
+			assert.Equal(t, newExpiredAt, ext.ExpiredAt)
+		}))
+
+		s := New(mockRepo, NewRolePolicy(), testValidator, testLifecycle)
+		_, err := s.ExtendAdvert(ctx, &advertproto.ExtendAdvertIn{Id: ID, ExpiredAt: timestamppb.New(newExpiredAt)})
+		assert.NoError(t, err)
+	})
+
+	failures := []struct {
+		name   string
+		advert *model.AdvertLifecycle
+		in     *advertproto.ExtendAdvertIn
+		want   error
+	}{
+		{
+			name: "renewal_window_closed",
+			advert: &model.AdvertLifecycle{
+				Status:    model.StatusExpired,
+				ExpiredAt: timePtr(time.Now().Add(-testLifecycle.RenewalGracePeriod - time.Hour)),
+			},
+			in:   &advertproto.ExtendAdvertIn{Id: ID, Duration: durationpb.New(time.Hour)},
+			want: model.ErrRenewalWindowClosed,
+		},
+		{
+			name: "max_lifetime_exceeded",
+			advert: &model.AdvertLifecycle{
+				Status:    model.StatusActive,
+				ExpiredAt: timePtr(time.Now().Add(time.Hour)),
+				CreatedAt: &createdAt,
+			},
+			in:   &advertproto.ExtendAdvertIn{Id: ID, Duration: durationpb.New(testLifecycle.MaxAdvertLifetime)},
+			want: model.ErrMaxLifetimeExceeded,
+		},
+		{
+			name: "expiry_not_later",
+			advert: &model.AdvertLifecycle{
+				Status:    model.StatusActive,
+				ExpiredAt: timePtr(time.Now().Add(48 * time.Hour)),
+			},
+			in:   &advertproto.ExtendAdvertIn{Id: ID, ExpiredAt: timestamppb.New(time.Now().Add(24 * time.Hour))},
+			want: model.ErrExpiryNotLater,
+		},
+		{
+			name:   "canceled_not_extendable",
+			advert: &model.AdvertLifecycle{Status: model.StatusCanceled},
+			in:     &advertproto.ExtendAdvertIn{Id: ID, Duration: durationpb.New(time.Hour)},
+			want:   model.ErrAdvertNotExtendable,
+		},
+	}
+
+	for _, tt := range failures {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.advert.ID = ID
+			tt.advert.OwnerUUID = uuid
+
+			mockLogger.EXPECT().AddFuncName("ExtendAdvert")
+			mockRepo.EXPECT().ExtendAdvert(ctx, ID, uuid, gomock.Any()).DoAndReturn(extendWith(tt.advert, nil))
+			mockLogger.EXPECT().Error(gomock.Any())
+
+			s := New(mockRepo, NewRolePolicy(), testValidator, testLifecycle)
+			_, err := s.ExtendAdvert(ctx, tt.in)
+
+			st, ok := status.FromError(err)
+			assert.True(t, ok)
+			assert.Equal(t, codes.FailedPrecondition, st.Code())
+			assert.Contains(t, st.Message(), tt.want.Error())
+		})
+	}
+
+	t.Run("extend_not_owner", func(t *testing.T) {
+		advert := &model.AdvertLifecycle{ID: ID, OwnerUUID: "other-uuid", Status: model.StatusActive}
+
+		mockLogger.EXPECT().AddFuncName("ExtendAdvert")
+		mockRepo.EXPECT().ExtendAdvert(ctx, ID, uuid, gomock.Any()).DoAndReturn(extendWith(advert, nil))
+		mockLogger.EXPECT().Error(gomock.Any())
+
+		s := New(mockRepo, NewRolePolicy(), testValidator, testLifecycle)
+		_, err := s.ExtendAdvert(ctx, &advertproto.ExtendAdvertIn{Id: ID, Duration: durationpb.New(time.Hour)})
+
+		st, ok := status.FromError(err)
+		assert.True(t, ok)
+		assert.Equal(t, codes.PermissionDenied, st.Code())
+	})
+
+	t.Run("extend_invalid", func(t *testing.T) {
+		mockLogger.EXPECT().AddFuncName("ExtendAdvert")
+		mockLogger.EXPECT().Error(gomock.Any())
+
+		s := New(mockRepo, NewRolePolicy(), testValidator, testLifecycle)
+		_, err := s.ExtendAdvert(ctx, &advertproto.ExtendAdvertIn{Id: ID})
+
+		st, ok := status.FromError(err)
+		assert.True(t, ok)
+		assert.Equal(t, codes.InvalidArgument, st.Code())
+	})
+
+	t.Run("extend_no_uuid", func(t *testing.T) {
+		testCtx := context.WithValue(context.Background(), config.KeyLogger, mockLogger)
+
+		mockLogger.EXPECT().AddFuncName("ExtendAdvert")
+		mockLogger.EXPECT().Error("failed to find uuid")
+
+		s := New(mockRepo, NewRolePolicy(), testValidator, testLifecycle)
+		_, err := s.ExtendAdvert(testCtx, &advertproto.ExtendAdvertIn{Id: ID})
+
+		st, ok := status.FromError(err)
+		assert.True(t, ok)
+		assert.Equal(t, codes.Unauthenticated, st.Code())
+	})
+}
+
+func timePtr(t time.Time) *time.Time {
+	return &t
+}
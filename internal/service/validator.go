@@ -0,0 +1,203 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/s21platform/advert-service/internal/config"
+	"github.com/s21platform/advert-service/internal/model"
+	advert_api "github.com/s21platform/advert-service/pkg/advert"
+)
+
+// ValidationError содержит нарушения по каждому полю запроса
+type ValidationError struct {
+	Violations []*errdetails.BadRequest_FieldViolation
+}
+
+func (e *ValidationError) Error() string {
+	parts := make([]string, 0, len(e.Violations))
+	for _, v := range e.Violations {
+		parts = append(parts, fmt.Sprintf("%s: %s", v.Field, v.Description))
+	}
+	return "invalid request: " + strings.Join(parts, "; ")
+}
+
+// Validator проверяет входящие запросы по лимитам из конфигурации
+type Validator struct {
+	limits      config.Validation
+	maxLifetime time.Duration
+	now         func() time.Time
+}
+
+// NewValidator принимает maxLifetime из config.Lifecycle, чтобы создание и продление ограничивал один лимит
+func NewValidator(limits config.Validation, maxLifetime time.Duration) *Validator {
+	return &Validator{limits: limits, maxLifetime: maxLifetime, now: time.Now}
+}
+
+type violations []*errdetails.BadRequest_FieldViolation
+
+func (v *violations) add(field, format string, args ...any) {
+	*v = append(*v, &errdetails.BadRequest_FieldViolation{
+		Field:       field,
+		Description: fmt.Sprintf(format, args...),
+	})
+}
+
+func (v violations) err() error {
+	if len(v) == 0 {
+		return nil
+	}
+	return &ValidationError{Violations: v}
+}
+
+func (val *Validator) CreateAdvert(in *advert_api.CreateAdvertIn) error {
+	var v violations
+
+	val.checkTitle(&v, in.GetTitle())
+	val.checkText(&v, in.GetTextContent())
+	val.checkOs(&v, "user.os", in.GetUser().GetOs())
+
+	val.checkTimestamp(&v, in.GetExpiredAt())
+	val.checkStartAt(&v, in.GetStartAt(), in.GetExpiredAt())
+	val.checkLifetime(&v, in.GetStartAt(), in.GetExpiredAt())
+
+	return v.err()
+}
+
+func (val *Validator) EditAdvert(in *advert_api.EditAdvertIn) error {
+	var v violations
+
+	if in.GetId() <= 0 {
+		v.add("id", "must be positive")
+	}
+
+	for _, field := range model.EditFields(in.GetUpdateMask()) {
+		switch field {
+		case model.EditFieldTitle:
+			val.checkTitle(&v, in.GetTitle())
+		case model.EditFieldTextContent:
+			val.checkText(&v, in.GetTextContent())
+		case model.EditFieldUserFilter:
+			val.checkOs(&v, "user_filter.os", in.GetUserFilter().GetOs())
+		case model.EditFieldExpiredAt:
+			val.checkTimestamp(&v, in.GetExpiredAt())
+		case model.EditFieldStartAt:
+			val.checkStartAt(&v, in.GetStartAt(), in.GetExpiredAt())
+		default:
+			v.add("update_mask", "field %q can not be edited", field)
+		}
+	}
+
+	return v.err()
+}
+
+func (val *Validator) ExtendAdvert(in *advert_api.ExtendAdvertIn) error {
+	var v violations
+
+	if in.GetId() <= 0 {
+		v.add("id", "must be positive")
+	}
+
+	switch {
+	case in.GetExpiredAt() != nil && in.GetDuration() != nil:
+		v.add("expired_at", "must not be set together with duration")
+	case in.GetExpiredAt() != nil:
+		val.checkTimestamp(&v, in.GetExpiredAt())
+	case in.GetDuration() != nil:
+		if err := in.GetDuration().CheckValid(); err != nil || in.GetDuration().AsDuration() <= 0 {
+			v.add("duration", "must be a positive duration")
+		}
+	default:
+		v.add("expired_at", "either expired_at or duration must be set")
+	}
+
+	return v.err()
+}
+
+func (val *Validator) checkTitle(v *violations, title string) {
+	if strings.TrimSpace(title) == "" {
+		v.add("title", "must not be empty")
+		return
+	}
+	if n := utf8.RuneCountInString(title); n > val.limits.TitleMaxLen {
+		v.add("title", "must be at most %d characters, got %d", val.limits.TitleMaxLen, n)
+	}
+}
+
+func (val *Validator) checkText(v *violations, text string) {
+	if n := utf8.RuneCountInString(text); n > val.limits.TextMaxLen {
+		v.add("text_content", "must be at most %d characters, got %d", val.limits.TextMaxLen, n)
+	}
+}
+
+func (val *Validator) checkOs(v *violations, field string, os []int64) {
+	if len(os) > val.limits.MaxOsCount {
+		v.add(field, "must contain at most %d values, got %d", val.limits.MaxOsCount, len(os))
+		return
+	}
+
+	seen := make(map[int64]struct{}, len(os))
+	for _, id := range os {
+		if id <= 0 {
+			v.add(field, "os id must be positive, got %d", id)
+			return
+		}
+		if _, ok := seen[id]; ok {
+			v.add(field, "os id %d is duplicated", id)
+			return
+		}
+		seen[id] = struct{}{}
+	}
+}
+
+func (val *Validator) checkTimestamp(v *violations, ts *timestamppb.Timestamp) {
+	if ts == nil {
+		v.add("expired_at", "must be set")
+		return
+	}
+	if err := ts.CheckValid(); err != nil {
+		v.add("expired_at", "must be a valid timestamp")
+		return
+	}
+	val.checkExpiredAt(v, ts.AsTime())
+}
+
+// checkStartAt проверяет необязательный start_at; без expired_at в запросе порядок проверит ограничение в базе
+func (val *Validator) checkStartAt(v *violations, startAt, expiredAt *timestamppb.Timestamp) {
+	if startAt == nil {
+		return
+	}
+	if err := startAt.CheckValid(); err != nil {
+		v.add("start_at", "must be a valid timestamp")
+		return
+	}
+	if expiredAt != nil && expiredAt.CheckValid() == nil && !startAt.AsTime().Before(expiredAt.AsTime()) {
+		v.add("start_at", "must be before expired_at")
+	}
+}
+
+func (val *Validator) checkExpiredAt(v *violations, expiredAt time.Time) {
+	if !expiredAt.After(val.now()) {
+		v.add("expired_at", "must be in the future")
+	}
+}
+
+// checkLifetime ограничивает срок нового объявления от момента публикации, как Service.checkLifetime при изменении срока
+func (val *Validator) checkLifetime(v *violations, startAt, expiredAt *timestamppb.Timestamp) {
+	if val.maxLifetime <= 0 || expiredAt.CheckValid() != nil {
+		return
+	}
+
+	liveSince := val.now()
+	if startAt.CheckValid() == nil && startAt.AsTime().After(liveSince) {
+		liveSince = startAt.AsTime()
+	}
+	if expiredAt.AsTime().Sub(liveSince) > val.maxLifetime {
+		v.add("expired_at", "must be within %s from publication", val.maxLifetime)
+	}
+}
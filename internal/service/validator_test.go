@@ -0,0 +1,276 @@
+package service
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/s21platform/advert-service/internal/config"
+	advertproto "github.com/s21platform/advert-service/pkg/advert"
+)
+
+var testValidator = NewValidator(config.Validation{
+	TitleMaxLen: 10,
+	TextMaxLen:  20,
+	MaxOsCount:  3,
+}, testLifecycle.MaxAdvertLifetime)
+
+func TestValidator_CreateAdvert(t *testing.T) {
+	t.Parallel()
+
+	future := timestamppb.New(time.Now().Add(time.Hour))
+
+	tests := []struct {
+		name       string
+		in         *advertproto.CreateAdvertIn
+		wantFields []string
+	}{
+		{
+			name: "ok",
+			in:   &advertproto.CreateAdvertIn{Title: "заголовок", TextContent: "текст", ExpiredAt: future},
+		},
+		{
+			name:       "empty",
+			in:         &advertproto.CreateAdvertIn{},
+			wantFields: []string{"title", "expired_at"},
+		},
+		{
+			name: "too_long",
+			in: &advertproto.CreateAdvertIn{
+				Title:       strings.Repeat("я", 11),
+				TextContent: strings.Repeat("я", 21),
+				ExpiredAt:   future,
+			},
+			wantFields: []string{"title", "text_content"},
+		},
+		{
+			name: "expired_in_past",
+			in: &advertproto.CreateAdvertIn{
+				Title:     "заголовок",
+				ExpiredAt: timestamppb.New(time.Now().Add(-time.Hour)),
+			},
+			wantFields: []string{"expired_at"},
+		},
+		{
+			name: "expired_too_far",
+			in: &advertproto.CreateAdvertIn{
+				Title:     "заголовок",
+				ExpiredAt: timestamppb.New(time.Now().Add(365 * 24 * time.Hour)),
+			},
+			wantFields: []string{"expired_at"},
+		},
+		{
+			name: "lifetime_counted_from_start",
+			in: &advertproto.CreateAdvertIn{
+				Title:     "заголовок",
+				StartAt:   timestamppb.New(time.Now().Add(10 * 24 * time.Hour)),
+				ExpiredAt: timestamppb.New(time.Now().Add(35 * 24 * time.Hour)),
+			},
+		},
+		{
+			name: "invalid_timestamp",
+			in: &advertproto.CreateAdvertIn{
+				Title:     "заголовок",
+				ExpiredAt: &timestamppb.Timestamp{Nanos: -1},
+			},
+			wantFields: []string{"expired_at"},
+		},
+		{
+			name: "scheduled",
+			in: &advertproto.CreateAdvertIn{
+				Title:     "заголовок",
+				StartAt:   timestamppb.New(time.Now().Add(30 * time.Minute)),
+				ExpiredAt: future,
+			},
+		},
+		{
+			name: "start_after_expiry",
+			in: &advertproto.CreateAdvertIn{
+				Title:     "заголовок",
+				StartAt:   timestamppb.New(time.Now().Add(2 * time.Hour)),
+				ExpiredAt: future,
+			},
+			wantFields: []string{"start_at"},
+		},
+		{
+			name: "bad_os",
+			in: &advertproto.CreateAdvertIn{
+				Title:     "заголовок",
+				User:      &advertproto.UserFilter{Os: []int64{0}},
+				ExpiredAt: future,
+			},
+			wantFields: []string{"user.os"},
+		},
+		{
+			name: "duplicated_os",
+			in: &advertproto.CreateAdvertIn{
+				Title:     "заголовок",
+				User:      &advertproto.UserFilter{Os: []int64{1, 1}},
+				ExpiredAt: future,
+			},
+			wantFields: []string{"user.os"},
+		},
+		{
+			name: "too_many_os",
+			in: &advertproto.CreateAdvertIn{
+				Title:     "заголовок",
+				User:      &advertproto.UserFilter{Os: []int64{1, 2, 3, 4}},
+				ExpiredAt: future,
+			},
+			wantFields: []string{"user.os"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertViolations(t, testValidator.CreateAdvert(tt.in), tt.wantFields)
+		})
+	}
+}
+
+func TestValidator_EditAdvert(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		in         *advertproto.EditAdvertIn
+		wantFields []string
+	}{
+		{
+			name: "ok",
+			in:   &advertproto.EditAdvertIn{Id: 1, Title: "заголовок"},
+		},
+		{
+			name:       "empty",
+			in:         &advertproto.EditAdvertIn{},
+			wantFields: []string{"id", "title"},
+		},
+		{
+			name: "bad_os",
+			in: &advertproto.EditAdvertIn{
+				Id:         1,
+				Title:      "заголовок",
+				UserFilter: &advertproto.UserFilter{Os: []int64{-5}},
+			},
+			wantFields: []string{"user_filter.os"},
+		},
+		{
+			name: "mask_skips_unmasked",
+			in: &advertproto.EditAdvertIn{
+				Id:          1,
+				TextContent: "текст",
+				UpdateMask:  &fieldmaskpb.FieldMask{Paths: []string{"text_content"}},
+			},
+		},
+		{
+			name: "mask_expired_at_required",
+			in: &advertproto.EditAdvertIn{
+				Id:         1,
+				UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"expired_at"}},
+			},
+			wantFields: []string{"expired_at"},
+		},
+		{
+			name: "mask_expired_at",
+			in: &advertproto.EditAdvertIn{
+				Id:         1,
+				ExpiredAt:  timestamppb.New(time.Now().Add(time.Hour)),
+				UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"expired_at"}},
+			},
+		},
+		{
+			name: "mask_start_at",
+			in: &advertproto.EditAdvertIn{
+				Id:         1,
+				StartAt:    timestamppb.New(time.Now().Add(2 * time.Hour)),
+				ExpiredAt:  timestamppb.New(time.Now().Add(time.Hour)),
+				UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"start_at", "expired_at"}},
+			},
+			wantFields: []string{"start_at"},
+		},
+		{
+			name: "mask_unknown_field",
+			in: &advertproto.EditAdvertIn{
+				Id:         1,
+				UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"status"}},
+			},
+			wantFields: []string{"update_mask"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertViolations(t, testValidator.EditAdvert(tt.in), tt.wantFields)
+		})
+	}
+}
+
+func assertViolations(t *testing.T, err error, wantFields []string) {
+	t.Helper()
+
+	if len(wantFields) == 0 {
+		assert.NoError(t, err)
+		return
+	}
+
+	var validationErr *ValidationError
+	if !assert.True(t, errors.As(err, &validationErr)) {
+		return
+	}
+
+	fields := make([]string, 0, len(validationErr.Violations))
+	for _, v := range validationErr.Violations {
+		fields = append(fields, v.Field)
+	}
+	assert.Equal(t, wantFields, fields)
+}
+
+func TestValidator_ExtendAdvert(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		in         *advertproto.ExtendAdvertIn
+		wantFields []string
+	}{
+		{
+			name: "ok_duration",
+			in:   &advertproto.ExtendAdvertIn{Id: 1, Duration: durationpb.New(time.Hour)},
+		},
+		{
+			name: "ok_expired_at",
+			in:   &advertproto.ExtendAdvertIn{Id: 1, ExpiredAt: timestamppb.New(time.Now().Add(time.Hour))},
+		},
+		{
+			name:       "empty",
+			in:         &advertproto.ExtendAdvertIn{},
+			wantFields: []string{"id", "expired_at"},
+		},
+		{
+			name: "both",
+			in: &advertproto.ExtendAdvertIn{
+				Id:        1,
+				ExpiredAt: timestamppb.New(time.Now().Add(time.Hour)),
+				Duration:  durationpb.New(time.Hour),
+			},
+			wantFields: []string{"expired_at"},
+		},
+		{
+			name:       "negative_duration",
+			in:         &advertproto.ExtendAdvertIn{Id: 1, Duration: durationpb.New(-time.Hour)},
+			wantFields: []string{"duration"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertViolations(t, testValidator.ExtendAdvert(tt.in), tt.wantFields)
+		})
+	}
+}
@@ -0,0 +1,18 @@
+//go:generate mockgen -destination=mock_contract_test.go -package=${GOPACKAGE} -source=contract.go
+package expiry
+
+import (
+	"context"
+	"time"
+
+	"github.com/s21platform/advert-service/internal/model"
+)
+
+type DBRepo interface {
+	ExpireAdverts(ctx context.Context, limit uint64, publish model.PublishExpiredFunc) (int, error)
+	RemindExpiring(ctx context.Context, leadTime, nextLeadTime time.Duration, limit uint64, publish model.PublishRemindersFunc) (int, error)
+}
+
+type Producer interface {
+	ProduceMessage(ctx context.Context, message any, key any) error
+}
@@ -0,0 +1,104 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: contract.go
+
+// Package expiry is a generated GoMock package.
+package expiry
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	model "github.com/s21platform/advert-service/internal/model"
+)
+
+// MockDBRepo is a mock of DBRepo interface.
+type MockDBRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockDBRepoMockRecorder
+}
+
+// MockDBRepoMockRecorder is the mock recorder for MockDBRepo.
+type MockDBRepoMockRecorder struct {
+	mock *MockDBRepo
+}
+
+// NewMockDBRepo creates a new mock instance.
+func NewMockDBRepo(ctrl *gomock.Controller) *MockDBRepo {
+	mock := &MockDBRepo{ctrl: ctrl}
+	mock.recorder = &MockDBRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDBRepo) EXPECT() *MockDBRepoMockRecorder {
+	return m.recorder
+}
+
+// ExpireAdverts mocks base method.
+func (m *MockDBRepo) ExpireAdverts(ctx context.Context, limit uint64, publish model.PublishExpiredFunc) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExpireAdverts", ctx, limit, publish)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExpireAdverts indicates an expected call of ExpireAdverts.
+func (mr *MockDBRepoMockRecorder) ExpireAdverts(ctx, limit, publish interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExpireAdverts", reflect.TypeOf((*MockDBRepo)(nil).ExpireAdverts), ctx, limit, publish)
+}
+
+// RemindExpiring mocks base method.
+func (m *MockDBRepo) RemindExpiring(ctx context.Context, leadTime, nextLeadTime time.Duration, limit uint64, publish model.PublishRemindersFunc) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemindExpiring", ctx, leadTime, nextLeadTime, limit, publish)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RemindExpiring indicates an expected call of RemindExpiring.
+func (mr *MockDBRepoMockRecorder) RemindExpiring(ctx, leadTime, nextLeadTime, limit, publish interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemindExpiring", reflect.TypeOf((*MockDBRepo)(nil).RemindExpiring), ctx, leadTime, nextLeadTime, limit, publish)
+}
+
+// MockProducer is a mock of Producer interface.
+type MockProducer struct {
+	ctrl     *gomock.Controller
+	recorder *MockProducerMockRecorder
+}
+
+// MockProducerMockRecorder is the mock recorder for MockProducer.
+type MockProducerMockRecorder struct {
+	mock *MockProducer
+}
+
+// NewMockProducer creates a new mock instance.
+func NewMockProducer(ctrl *gomock.Controller) *MockProducer {
+	mock := &MockProducer{ctrl: ctrl}
+	mock.recorder = &MockProducerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockProducer) EXPECT() *MockProducerMockRecorder {
+	return m.recorder
+}
+
+// ProduceMessage mocks base method.
+func (m *MockProducer) ProduceMessage(ctx context.Context, message, key any) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProduceMessage", ctx, message, key)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ProduceMessage indicates an expected call of ProduceMessage.
+func (mr *MockProducerMockRecorder) ProduceMessage(ctx, message, key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProduceMessage", reflect.TypeOf((*MockProducer)(nil).ProduceMessage), ctx, message, key)
+}
@@ -0,0 +1,130 @@
+package expiry
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"slices"
+	"time"
+
+	"github.com/s21platform/advert-service/internal/config"
+	"github.com/s21platform/advert-service/internal/model"
+)
+
+type Worker struct {
+	dbR              DBRepo
+	producer         Producer
+	reminderProducer Producer
+	interval         time.Duration
+	batchSize        uint64
+	leadTimes        []time.Duration
+}
+
+func New(dbR DBRepo, producer, reminderProducer Producer, cfg config.Expiry) *Worker {
+	return &Worker{
+		dbR:              dbR,
+		producer:         producer,
+		reminderProducer: reminderProducer,
+		interval:         cfg.Interval,
+		batchSize:        cfg.BatchSize,
+		leadTimes:        sortLeadTimes(cfg.ReminderLeadTimes),
+	}
+}
+
+// sortLeadTimes упорядочивает пороги по убыванию без повторов, чтобы у каждого был следующий меньший
+func sortLeadTimes(leadTimes []time.Duration) []time.Duration {
+	sorted := slices.Clone(leadTimes)
+	slices.SortFunc(sorted, func(a, b time.Duration) int { return cmp.Compare(b, a) })
+	return slices.Compact(sorted)
+}
+
+// Run переводит истёкшие объявления в expired раз в интервал, пока не отменён контекст
+func (w *Worker) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		expired, err := w.ExpireAll(ctx)
+		if err != nil {
+			log.Printf("failed to expire adverts: %v", err)
+		} else if expired > 0 {
+			log.Printf("expired %d adverts", expired)
+		}
+
+		reminded, err := w.RemindAll(ctx)
+		if err != nil {
+			log.Printf("failed to remind about expiring adverts: %v", err)
+		} else if reminded > 0 {
+			log.Printf("sent %d expiry reminders", reminded)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// ExpireAll обрабатывает пачки, пока находятся истёкшие объявления
+func (w *Worker) ExpireAll(ctx context.Context) (int, error) {
+	total := 0
+	for {
+		expired, err := w.dbR.ExpireAdverts(ctx, w.batchSize, w.publish)
+		if err != nil {
+			return total, err
+		}
+		total += expired
+
+		if uint64(expired) < w.batchSize || ctx.Err() != nil {
+			return total, nil
+		}
+	}
+}
+
+// RemindAll отправляет напоминания по каждому порогу; порог, упавший с ошибкой, не мешает остальным.
+// За один проход объявление получает не больше одного напоминания - по наименьшему наступившему порогу
+func (w *Worker) RemindAll(ctx context.Context) (int, error) {
+	total := 0
+	var errs []error
+	for i, leadTime := range w.leadTimes {
+		var nextLeadTime time.Duration
+		if i+1 < len(w.leadTimes) {
+			nextLeadTime = w.leadTimes[i+1]
+		}
+
+		for {
+			reminded, err := w.dbR.RemindExpiring(ctx, leadTime, nextLeadTime, w.batchSize, w.publishReminders)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("lead time %s: %w", leadTime, err))
+				break
+			}
+			total += reminded
+
+			if uint64(reminded) < w.batchSize || ctx.Err() != nil {
+				break
+			}
+		}
+	}
+	return total, errors.Join(errs...)
+}
+
+func (w *Worker) publishReminders(ctx context.Context, reminders []model.AdvertExpiryReminder) error {
+	for _, reminder := range reminders {
+		if err := w.reminderProducer.ProduceMessage(ctx, reminder, reminder.ID); err != nil {
+			return fmt.Errorf("failed to publish expiry reminder for advert %d: %w", reminder.ID, err)
+		}
+	}
+	return nil
+}
+
+func (w *Worker) publish(ctx context.Context, adverts []model.AdvertExpired) error {
+	for _, advert := range adverts {
+		if err := w.producer.ProduceMessage(ctx, advert, advert.ID); err != nil {
+			return fmt.Errorf("failed to publish advert.expired for advert %d: %w", advert.ID, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,163 @@
+package expiry
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/s21platform/advert-service/internal/config"
+	"github.com/s21platform/advert-service/internal/model"
+)
+
+// expireWith эмулирует ExpireAdverts репозитория над заданной пачкой объявлений
+func expireWith(adverts []model.AdvertExpired) func(context.Context, uint64, model.PublishExpiredFunc) (int, error) {
+	return func(ctx context.Context, _ uint64, publish model.PublishExpiredFunc) (int, error) {
+		if err := publish(ctx, adverts); err != nil {
+			return 0, err
+		}
+		return len(adverts), nil
+	}
+}
+
+func TestWorker_ExpireAll(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	cfg := config.Expiry{Interval: time.Minute, BatchSize: 2}
+
+	first := []model.AdvertExpired{{ID: 1, OwnerUUID: "a"}, {ID: 2, OwnerUUID: "b"}}
+	second := []model.AdvertExpired{{ID: 3, OwnerUUID: "c"}}
+
+	t.Run("expire_batches", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockRepo := NewMockDBRepo(ctrl)
+		mockProducer := NewMockProducer(ctrl)
+
+		gomock.InOrder(
+			mockRepo.EXPECT().ExpireAdverts(ctx, uint64(2), gomock.Any()).DoAndReturn(expireWith(first)),
+			mockRepo.EXPECT().ExpireAdverts(ctx, uint64(2), gomock.Any()).DoAndReturn(expireWith(second)),
+		)
+		for _, advert := range append(first, second...) {
+			mockProducer.EXPECT().ProduceMessage(ctx, advert, advert.ID).Return(nil)
+		}
+
+		expired, err := New(mockRepo, mockProducer, mockProducer, cfg).ExpireAll(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, 3, expired)
+	})
+
+	t.Run("publish_err", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockRepo := NewMockDBRepo(ctrl)
+		mockProducer := NewMockProducer(ctrl)
+		expectedErr := errors.New("kafka is down")
+
+		mockRepo.EXPECT().ExpireAdverts(ctx, uint64(2), gomock.Any()).DoAndReturn(expireWith(first))
+		mockProducer.EXPECT().ProduceMessage(ctx, first[0], first[0].ID).Return(expectedErr)
+
+		expired, err := New(mockRepo, mockProducer, mockProducer, cfg).ExpireAll(ctx)
+		assert.ErrorIs(t, err, expectedErr)
+		assert.Equal(t, 0, expired)
+	})
+
+	t.Run("repo_err", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockRepo := NewMockDBRepo(ctrl)
+		mockProducer := NewMockProducer(ctrl)
+		expectedErr := errors.New("db error")
+
+		mockRepo.EXPECT().ExpireAdverts(ctx, uint64(2), gomock.Any()).Return(0, expectedErr)
+
+		_, err := New(mockRepo, mockProducer, mockProducer, cfg).ExpireAll(ctx)
+		assert.ErrorIs(t, err, expectedErr)
+	})
+}
+
+// remindWith эмулирует RemindExpiring репозитория над заданной пачкой напоминаний
+func remindWith(reminders []model.AdvertExpiryReminder) func(context.Context, time.Duration, time.Duration, uint64, model.PublishRemindersFunc) (int, error) {
+	return func(ctx context.Context, _, _ time.Duration, _ uint64, publish model.PublishRemindersFunc) (int, error) {
+		if err := publish(ctx, reminders); err != nil {
+			return 0, err
+		}
+		return len(reminders), nil
+	}
+}
+
+func TestWorker_RemindAll(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	cfg := config.Expiry{Interval: time.Minute, BatchSize: 10, ReminderLeadTimes: []time.Duration{72 * time.Hour, 24 * time.Hour}}
+
+	t.Run("remind_each_lead_time", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockRepo := NewMockDBRepo(ctrl)
+		mockProducer := NewMockProducer(ctrl)
+		mockReminderProducer := NewMockProducer(ctrl)
+
+		early := model.AdvertExpiryReminder{ID: 1, OwnerUUID: "a", HoursLeft: 70}
+		late := model.AdvertExpiryReminder{ID: 2, OwnerUUID: "b", HoursLeft: 24}
+
+		mockRepo.EXPECT().RemindExpiring(ctx, 72*time.Hour, 24*time.Hour, uint64(10), gomock.Any()).
+			DoAndReturn(remindWith([]model.AdvertExpiryReminder{early}))
+		mockRepo.EXPECT().RemindExpiring(ctx, 24*time.Hour, time.Duration(0), uint64(10), gomock.Any()).
+			DoAndReturn(remindWith([]model.AdvertExpiryReminder{late}))
+		mockReminderProducer.EXPECT().ProduceMessage(ctx, early, early.ID).Return(nil)
+		mockReminderProducer.EXPECT().ProduceMessage(ctx, late, late.ID).Return(nil)
+
+		reminded, err := New(mockRepo, mockProducer, mockReminderProducer, cfg).RemindAll(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, 2, reminded)
+	})
+
+	t.Run("failed_lead_time_does_not_block_others", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockRepo := NewMockDBRepo(ctrl)
+		mockProducer := NewMockProducer(ctrl)
+		mockReminderProducer := NewMockProducer(ctrl)
+		expectedErr := errors.New("db error")
+
+		late := model.AdvertExpiryReminder{ID: 2, OwnerUUID: "b", HoursLeft: 24}
+
+		mockRepo.EXPECT().RemindExpiring(ctx, 72*time.Hour, 24*time.Hour, uint64(10), gomock.Any()).Return(0, expectedErr)
+		mockRepo.EXPECT().RemindExpiring(ctx, 24*time.Hour, time.Duration(0), uint64(10), gomock.Any()).
+			DoAndReturn(remindWith([]model.AdvertExpiryReminder{late}))
+		mockReminderProducer.EXPECT().ProduceMessage(ctx, late, late.ID).Return(nil)
+
+		reminded, err := New(mockRepo, mockProducer, mockReminderProducer, cfg).RemindAll(ctx)
+		assert.ErrorIs(t, err, expectedErr)
+		assert.Equal(t, 1, reminded)
+	})
+
+	t.Run("lead_times_sorted_descending", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockRepo := NewMockDBRepo(ctrl)
+		mockProducer := NewMockProducer(ctrl)
+
+		// порядок и повторы в конфиге не влияют на окна порогов
+		unsorted := config.Expiry{BatchSize: 10, ReminderLeadTimes: []time.Duration{24 * time.Hour, 72 * time.Hour, 24 * time.Hour}}
+
+		gomock.InOrder(
+			mockRepo.EXPECT().RemindExpiring(ctx, 72*time.Hour, 24*time.Hour, uint64(10), gomock.Any()).Return(0, nil),
+			mockRepo.EXPECT().RemindExpiring(ctx, 24*time.Hour, time.Duration(0), uint64(10), gomock.Any()).Return(0, nil),
+		)
+
+		reminded, err := New(mockRepo, mockProducer, mockProducer, unsorted).RemindAll(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, 0, reminded)
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockRepo := NewMockDBRepo(ctrl)
+		mockProducer := NewMockProducer(ctrl)
+
+		reminded, err := New(mockRepo, mockProducer, mockProducer, config.Expiry{BatchSize: 10}).RemindAll(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, 0, reminded)
+	})
+}
@@ -0,0 +1,17 @@
+//go:generate mockgen -destination=mock_contract_test.go -package=${GOPACKAGE} -source=contract.go
+package outbox
+
+import (
+	"context"
+
+	"github.com/s21platform/advert-service/internal/infra"
+	"github.com/s21platform/advert-service/internal/model"
+)
+
+type DBRepo interface {
+	ProcessOutbox(ctx context.Context, limit uint64, publish model.PublishOutboxFunc, backoff model.BackoffFunc) (int, error)
+}
+
+type Producer interface {
+	ProduceMessages(ctx context.Context, messages []infra.Message) []error
+}
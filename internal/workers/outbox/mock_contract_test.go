@@ -0,0 +1,89 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: contract.go
+
+// Package outbox is a generated GoMock package.
+package outbox
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	infra "github.com/s21platform/advert-service/internal/infra"
+	model "github.com/s21platform/advert-service/internal/model"
+)
+
+// MockDBRepo is a mock of DBRepo interface.
+type MockDBRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockDBRepoMockRecorder
+}
+
+// MockDBRepoMockRecorder is the mock recorder for MockDBRepo.
+type MockDBRepoMockRecorder struct {
+	mock *MockDBRepo
+}
+
+// NewMockDBRepo creates a new mock instance.
+func NewMockDBRepo(ctrl *gomock.Controller) *MockDBRepo {
+	mock := &MockDBRepo{ctrl: ctrl}
+	mock.recorder = &MockDBRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDBRepo) EXPECT() *MockDBRepoMockRecorder {
+	return m.recorder
+}
+
+// ProcessOutbox mocks base method.
+func (m *MockDBRepo) ProcessOutbox(ctx context.Context, limit uint64, publish model.PublishOutboxFunc, backoff model.BackoffFunc) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProcessOutbox", ctx, limit, publish, backoff)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ProcessOutbox indicates an expected call of ProcessOutbox.
+func (mr *MockDBRepoMockRecorder) ProcessOutbox(ctx, limit, publish, backoff interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProcessOutbox", reflect.TypeOf((*MockDBRepo)(nil).ProcessOutbox), ctx, limit, publish, backoff)
+}
+
+// MockProducer is a mock of Producer interface.
+type MockProducer struct {
+	ctrl     *gomock.Controller
+	recorder *MockProducerMockRecorder
+}
+
+// MockProducerMockRecorder is the mock recorder for MockProducer.
+type MockProducerMockRecorder struct {
+	mock *MockProducer
+}
+
+// NewMockProducer creates a new mock instance.
+func NewMockProducer(ctrl *gomock.Controller) *MockProducer {
+	mock := &MockProducer{ctrl: ctrl}
+	mock.recorder = &MockProducerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockProducer) EXPECT() *MockProducerMockRecorder {
+	return m.recorder
+}
+
+// ProduceMessages mocks base method.
+func (m *MockProducer) ProduceMessages(ctx context.Context, messages []infra.Message) []error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProduceMessages", ctx, messages)
+	ret0, _ := ret[0].([]error)
+	return ret0
+}
+
+// ProduceMessages indicates an expected call of ProduceMessages.
+func (mr *MockProducerMockRecorder) ProduceMessages(ctx, messages interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProduceMessages", reflect.TypeOf((*MockProducer)(nil).ProduceMessages), ctx, messages)
+}
@@ -0,0 +1,100 @@
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/s21platform/advert-service/internal/config"
+	"github.com/s21platform/advert-service/internal/infra"
+	"github.com/s21platform/advert-service/internal/model"
+)
+
+type Relay struct {
+	dbR         DBRepo
+	producer    Producer
+	interval    time.Duration
+	batchSize   uint64
+	baseBackoff time.Duration
+	maxBackoff  time.Duration
+}
+
+func New(dbR DBRepo, producer Producer, cfg config.Outbox) *Relay {
+	return &Relay{
+		dbR:         dbR,
+		producer:    producer,
+		interval:    cfg.Interval,
+		batchSize:   cfg.BatchSize,
+		baseBackoff: cfg.BaseBackoff,
+		maxBackoff:  cfg.MaxBackoff,
+	}
+}
+
+// Run переносит события из outbox в kafka раз в интервал, пока не отменён контекст
+func (r *Relay) Run(ctx context.Context) {
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		published, err := r.RelayAll(ctx)
+		if err != nil {
+			log.Printf("failed to relay advert events: %v", err)
+		} else if published > 0 {
+			log.Printf("published %d advert events", published)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RelayAll обрабатывает пачки, пока в outbox есть готовые к отправке события
+func (r *Relay) RelayAll(ctx context.Context) (int, error) {
+	total := 0
+	for {
+		published, err := r.dbR.ProcessOutbox(ctx, r.batchSize, r.publish, r.Backoff)
+		if err != nil {
+			return total, err
+		}
+		total += published
+
+		if uint64(published) < r.batchSize || ctx.Err() != nil {
+			return total, nil
+		}
+	}
+}
+
+// Backoff удваивает задержку с каждой неудачной попыткой, но не больше maxBackoff
+func (r *Relay) Backoff(attempts int) time.Duration {
+	delay := r.baseBackoff
+	for i := 1; i < attempts && delay < r.maxBackoff; i++ {
+		delay *= 2
+	}
+	return min(delay, r.maxBackoff)
+}
+
+func (r *Relay) publish(ctx context.Context, events []model.OutboxEvent) []error {
+	messages := make([]infra.Message, len(events))
+	for i, event := range events {
+		messages[i] = infra.Message{
+			// продолжает trace запроса, изменившего объявление
+			Ctx:   event.TraceContext.Extract(ctx),
+			Value: json.RawMessage(event.Payload),
+			// ключ - id объявления, чтобы события одного объявления попадали в одну партицию
+			Key: event.AdvertID,
+		}
+	}
+
+	errs := r.producer.ProduceMessages(ctx, messages)
+	for i, err := range errs {
+		if err != nil {
+			errs[i] = fmt.Errorf("failed to publish %s for advert %d: %w", events[i].Type, events[i].AdvertID, err)
+		}
+	}
+	return errs
+}
@@ -0,0 +1,160 @@
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/s21platform/advert-service/internal/config"
+	"github.com/s21platform/advert-service/internal/infra"
+	"github.com/s21platform/advert-service/internal/model"
+)
+
+func messagesOf(ctx context.Context, events ...model.OutboxEvent) []infra.Message {
+	messages := make([]infra.Message, 0, len(events))
+	for _, event := range events {
+		messages = append(messages, infra.Message{Ctx: ctx, Value: json.RawMessage(event.Payload), Key: event.AdvertID})
+	}
+	return messages
+}
+
+// processWith эмулирует ProcessOutbox репозитория: успешные события считаются отправленными,
+// для неудачных запрашивается задержка следующей попытки
+func processWith(events []model.OutboxEvent, delays *[]time.Duration) func(context.Context, uint64, model.PublishOutboxFunc, model.BackoffFunc) (int, error) {
+	return func(ctx context.Context, _ uint64, publish model.PublishOutboxFunc, backoff model.BackoffFunc) (int, error) {
+		published := 0
+		for i, err := range publish(ctx, events) {
+			if err != nil {
+				*delays = append(*delays, backoff(events[i].Attempts+1))
+				continue
+			}
+			published++
+		}
+		return published, nil
+	}
+}
+
+func TestRelay_RelayAll(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	cfg := config.Outbox{Interval: time.Second, BatchSize: 2, BaseBackoff: time.Second, MaxBackoff: time.Minute}
+
+	first := []model.OutboxEvent{
+		{ID: 1, AdvertID: 10, Type: model.EventAdvertCreated, Payload: []byte(`{"advert_id":10}`)},
+		{ID: 2, AdvertID: 11, Type: model.EventAdvertCreated, Payload: []byte(`{"advert_id":11}`)},
+	}
+	second := []model.OutboxEvent{
+		{ID: 3, AdvertID: 10, Type: model.EventAdvertEdited, Payload: []byte(`{"advert_id":10,"version":2}`)},
+	}
+
+	t.Run("relay_batches", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockRepo := NewMockDBRepo(ctrl)
+		mockProducer := NewMockProducer(ctrl)
+		var delays []time.Duration
+
+		gomock.InOrder(
+			mockRepo.EXPECT().ProcessOutbox(ctx, uint64(2), gomock.Any(), gomock.Any()).DoAndReturn(processWith(first, &delays)),
+			mockRepo.EXPECT().ProcessOutbox(ctx, uint64(2), gomock.Any(), gomock.Any()).DoAndReturn(processWith(second, &delays)),
+		)
+		mockProducer.EXPECT().ProduceMessages(ctx, messagesOf(ctx, first...)).Return([]error{nil, nil})
+		mockProducer.EXPECT().ProduceMessages(ctx, messagesOf(ctx, second...)).Return([]error{nil})
+
+		published, err := New(mockRepo, mockProducer, cfg).RelayAll(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, 3, published)
+		assert.Empty(t, delays)
+	})
+
+	t.Run("publish_err", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockRepo := NewMockDBRepo(ctrl)
+		mockProducer := NewMockProducer(ctrl)
+		var delays []time.Duration
+
+		failed := first[0]
+		failed.Attempts = 2
+
+		mockRepo.EXPECT().ProcessOutbox(ctx, uint64(2), gomock.Any(), gomock.Any()).
+			DoAndReturn(processWith([]model.OutboxEvent{failed, first[1]}, &delays))
+		mockProducer.EXPECT().ProduceMessages(ctx, messagesOf(ctx, failed, first[1])).Return([]error{errors.New("kafka is down"), nil})
+
+		published, err := New(mockRepo, mockProducer, cfg).RelayAll(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, published)
+		assert.Equal(t, []time.Duration{4 * time.Second}, delays)
+	})
+
+	t.Run("repo_err", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockRepo := NewMockDBRepo(ctrl)
+		mockProducer := NewMockProducer(ctrl)
+		expectedErr := errors.New("db is down")
+
+		mockRepo.EXPECT().ProcessOutbox(ctx, uint64(2), gomock.Any(), gomock.Any()).Return(0, expectedErr)
+
+		_, err := New(mockRepo, mockProducer, cfg).RelayAll(ctx)
+		assert.ErrorIs(t, err, expectedErr)
+	})
+}
+
+func TestRelay_Backoff(t *testing.T) {
+	t.Parallel()
+
+	relay := New(nil, nil, config.Outbox{BaseBackoff: time.Second, MaxBackoff: 10 * time.Second})
+
+	assert.Equal(t, time.Second, relay.Backoff(1))
+	assert.Equal(t, 2*time.Second, relay.Backoff(2))
+	assert.Equal(t, 8*time.Second, relay.Backoff(4))
+	assert.Equal(t, 10*time.Second, relay.Backoff(5))
+	assert.Equal(t, 10*time.Second, relay.Backoff(100))
+}
+
+func TestRelay_ContinuesTrace(t *testing.T) {
+	t.Parallel()
+
+	// как InitTracing: без propagator trace context не сохраняется
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+
+	traceID, err := trace.TraceIDFromHex("4bf92f3577b34da6a3ce929d0e0e4736")
+	assert.NoError(t, err)
+	spanID, err := trace.SpanIDFromHex("00f067aa0ba902b7")
+	assert.NoError(t, err)
+	requestCtx := trace.ContextWithSpanContext(context.Background(), trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID: traceID, SpanID: spanID, TraceFlags: trace.FlagsSampled,
+	}))
+
+	// insertOutbox пишет NewTraceContext в trace_context, ProcessOutbox читает колонку обратно через Scan
+	value, err := model.NewTraceContext(requestCtx).Value()
+	assert.NoError(t, err)
+	var stored model.TraceContext
+	assert.NoError(t, stored.Scan(value))
+
+	events := []model.OutboxEvent{{ID: 1, AdvertID: 10, Type: model.EventAdvertEdited, Payload: []byte(`{"advert_id":10}`), TraceContext: stored}}
+
+	ctrl := gomock.NewController(t)
+	mockRepo := NewMockDBRepo(ctrl)
+	mockProducer := NewMockProducer(ctrl)
+	var delays []time.Duration
+
+	ctx := context.Background()
+	mockRepo.EXPECT().ProcessOutbox(ctx, uint64(2), gomock.Any(), gomock.Any()).DoAndReturn(processWith(events, &delays))
+	mockProducer.EXPECT().ProduceMessages(ctx, gomock.Len(1)).DoAndReturn(func(_ context.Context, messages []infra.Message) []error {
+		assert.Equal(t, traceID, trace.SpanContextFromContext(messages[0].Ctx).TraceID())
+		return []error{nil}
+	})
+
+	cfg := config.Outbox{Interval: time.Second, BatchSize: 2, BaseBackoff: time.Second, MaxBackoff: time.Minute}
+	published, err := New(mockRepo, mockProducer, cfg).RelayAll(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, published)
+}
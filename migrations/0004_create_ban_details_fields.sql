@@ -0,0 +1,13 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE advert_text
+    ADD COLUMN IF NOT EXISTS ban_reason TEXT,
+    ADD COLUMN IF NOT EXISTS banned_by UUID;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE advert_text
+    DROP COLUMN IF EXISTS ban_reason,
+    DROP COLUMN IF EXISTS banned_by;
+-- +goose StatementEnd
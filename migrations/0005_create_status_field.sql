@@ -0,0 +1,39 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE advert_text
+    ADD COLUMN IF NOT EXISTS status TEXT NOT NULL DEFAULT 'active';
+
+UPDATE advert_text
+SET status = CASE
+                 WHEN is_banned THEN 'banned'
+                 WHEN is_canceled THEN 'canceled'
+                 WHEN expired_at <= NOW() THEN 'expired'
+                 ELSE 'active'
+    END;
+
+ALTER TABLE advert_text
+    ADD CONSTRAINT advert_text_status_check CHECK (status IN
+                                                   ('draft', 'pending_review', 'active', 'paused', 'canceled',
+                                                    'banned', 'expired', 'archived')),
+    DROP COLUMN IF EXISTS is_canceled,
+    DROP COLUMN IF EXISTS is_banned;
+
+CREATE INDEX IF NOT EXISTS advert_text_status_idx ON advert_text (status);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS advert_text_status_idx;
+
+ALTER TABLE advert_text
+    ADD COLUMN IF NOT EXISTS is_canceled BOOLEAN DEFAULT FALSE,
+    ADD COLUMN IF NOT EXISTS is_banned BOOLEAN DEFAULT FALSE;
+
+UPDATE advert_text
+SET is_canceled = status = 'canceled',
+    is_banned   = status = 'banned';
+
+ALTER TABLE advert_text
+    DROP CONSTRAINT IF EXISTS advert_text_status_check,
+    DROP COLUMN IF EXISTS status;
+-- +goose StatementEnd
@@ -0,0 +1,9 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE INDEX IF NOT EXISTS advert_text_owner_created_at_idx ON advert_text (owner_uuid, created_at, id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS advert_text_owner_created_at_idx;
+-- +goose StatementEnd
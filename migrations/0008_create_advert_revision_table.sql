@@ -0,0 +1,25 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS advert_revision
+(
+    id           BIGSERIAL PRIMARY KEY,
+    advert_id    INTEGER   NOT NULL REFERENCES advert_text (id) ON DELETE CASCADE,
+    version      BIGINT    NOT NULL,
+    title        TEXT      NOT NULL,
+    text_content TEXT,
+    filter       JSONB,
+    expired_at   TIMESTAMP,
+    edited_by    UUID      NOT NULL,
+    created_at   TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (advert_id, version)
+);
+
+INSERT INTO advert_revision (advert_id, version, title, text_content, filter, expired_at, edited_by)
+SELECT id, version, title, text_content, filter, expired_at, owner_uuid
+FROM advert_text;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS advert_revision;
+-- +goose StatementEnd
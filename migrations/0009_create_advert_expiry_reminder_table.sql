@@ -0,0 +1,16 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS advert_expiry_reminder
+(
+    advert_id    INTEGER   NOT NULL REFERENCES advert_text (id) ON DELETE CASCADE,
+    lead_seconds BIGINT    NOT NULL,
+    expired_at   TIMESTAMP NOT NULL,
+    sent_at      TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (advert_id, lead_seconds, expired_at)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS advert_expiry_reminder;
+-- +goose StatementEnd
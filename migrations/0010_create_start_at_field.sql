@@ -0,0 +1,13 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE advert_text
+    ADD COLUMN IF NOT EXISTS start_at TIMESTAMP,
+    ADD CONSTRAINT advert_text_start_at_check CHECK (start_at IS NULL OR expired_at IS NULL OR start_at < expired_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE advert_text
+    DROP CONSTRAINT IF EXISTS advert_text_start_at_check,
+    DROP COLUMN IF EXISTS start_at;
+-- +goose StatementEnd
@@ -0,0 +1,15 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE advert_text
+    ADD COLUMN IF NOT EXISTS paused_at TIMESTAMP;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+UPDATE advert_text
+SET status = 'active'
+WHERE status = 'paused';
+
+ALTER TABLE advert_text
+    DROP COLUMN IF EXISTS paused_at;
+-- +goose StatementEnd
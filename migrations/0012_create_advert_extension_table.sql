@@ -0,0 +1,20 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS advert_extension
+(
+    id                  BIGSERIAL PRIMARY KEY,
+    advert_id           INTEGER   NOT NULL REFERENCES advert_text (id) ON DELETE CASCADE,
+    previous_expired_at TIMESTAMP,
+    new_expired_at      TIMESTAMP NOT NULL,
+    renewed             BOOLEAN   NOT NULL DEFAULT FALSE,
+    extended_by         UUID      NOT NULL,
+    created_at          TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS advert_extension_advert_id_idx ON advert_extension (advert_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS advert_extension;
+-- +goose StatementEnd
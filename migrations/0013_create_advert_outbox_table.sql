@@ -0,0 +1,22 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS advert_outbox
+(
+    id              BIGSERIAL PRIMARY KEY,
+    advert_id       INTEGER   NOT NULL,
+    event_type      TEXT      NOT NULL,
+    payload         JSONB     NOT NULL,
+    attempts        INTEGER   NOT NULL DEFAULT 0,
+    last_error      TEXT,
+    next_attempt_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    created_at      TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    published_at    TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS advert_outbox_pending_idx ON advert_outbox (advert_id, id) WHERE published_at IS NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS advert_outbox;
+-- +goose StatementEnd
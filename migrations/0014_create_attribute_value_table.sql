@@ -0,0 +1,16 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS attribute_value
+(
+    attribute  TEXT      NOT NULL,
+    value_id   BIGINT    NOT NULL,
+    name       TEXT      NOT NULL,
+    updated_at TIMESTAMP NOT NULL,
+    PRIMARY KEY (attribute, value_id)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS attribute_value;
+-- +goose StatementEnd
@@ -0,0 +1,19 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS attribute_dlq
+(
+    id          BIGSERIAL PRIMARY KEY,
+    payload     BYTEA     NOT NULL,
+    error       TEXT      NOT NULL,
+    attempts    INTEGER   NOT NULL,
+    created_at  TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    replayed_at TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS attribute_dlq_pending_idx ON attribute_dlq (id) WHERE replayed_at IS NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS attribute_dlq;
+-- +goose StatementEnd
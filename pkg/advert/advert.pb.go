@@ -7,9 +7,11 @@
 package advert
 
 import (
+	duration "github.com/golang/protobuf/ptypes/duration"
 	timestamp "github.com/golang/protobuf/ptypes/timestamp"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	fieldmaskpb "google.golang.org/protobuf/types/known/fieldmaskpb"
 	reflect "reflect"
 	sync "sync"
 	unsafe "unsafe"
@@ -22,6 +24,125 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+type AdvertStatus int32
+
+const (
+	AdvertStatus_ADVERT_STATUS_UNSPECIFIED    AdvertStatus = 0
+	AdvertStatus_ADVERT_STATUS_DRAFT          AdvertStatus = 1
+	AdvertStatus_ADVERT_STATUS_PENDING_REVIEW AdvertStatus = 2
+	AdvertStatus_ADVERT_STATUS_ACTIVE         AdvertStatus = 3
+	AdvertStatus_ADVERT_STATUS_PAUSED         AdvertStatus = 4
+	AdvertStatus_ADVERT_STATUS_CANCELED       AdvertStatus = 5
+	AdvertStatus_ADVERT_STATUS_BANNED         AdvertStatus = 6
+	AdvertStatus_ADVERT_STATUS_EXPIRED        AdvertStatus = 7
+	AdvertStatus_ADVERT_STATUS_ARCHIVED       AdvertStatus = 8
+	AdvertStatus_ADVERT_STATUS_SCHEDULED      AdvertStatus = 9
+)
+
+// Enum value maps for AdvertStatus.
+var (
+	AdvertStatus_name = map[int32]string{
+		0: "ADVERT_STATUS_UNSPECIFIED",
+		1: "ADVERT_STATUS_DRAFT",
+		2: "ADVERT_STATUS_PENDING_REVIEW",
+		3: "ADVERT_STATUS_ACTIVE",
+		4: "ADVERT_STATUS_PAUSED",
+		5: "ADVERT_STATUS_CANCELED",
+		6: "ADVERT_STATUS_BANNED",
+		7: "ADVERT_STATUS_EXPIRED",
+		8: "ADVERT_STATUS_ARCHIVED",
+		9: "ADVERT_STATUS_SCHEDULED",
+	}
+	AdvertStatus_value = map[string]int32{
+		"ADVERT_STATUS_UNSPECIFIED":    0,
+		"ADVERT_STATUS_DRAFT":          1,
+		"ADVERT_STATUS_PENDING_REVIEW": 2,
+		"ADVERT_STATUS_ACTIVE":         3,
+		"ADVERT_STATUS_PAUSED":         4,
+		"ADVERT_STATUS_CANCELED":       5,
+		"ADVERT_STATUS_BANNED":         6,
+		"ADVERT_STATUS_EXPIRED":        7,
+		"ADVERT_STATUS_ARCHIVED":       8,
+		"ADVERT_STATUS_SCHEDULED":      9,
+	}
+)
+
+func (x AdvertStatus) Enum() *AdvertStatus {
+	p := new(AdvertStatus)
+	*p = x
+	return p
+}
+
+func (x AdvertStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (AdvertStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_advert_proto_enumTypes[0].Descriptor()
+}
+
+func (AdvertStatus) Type() protoreflect.EnumType {
+	return &file_api_advert_proto_enumTypes[0]
+}
+
+func (x AdvertStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use AdvertStatus.Descriptor instead.
+func (AdvertStatus) EnumDescriptor() ([]byte, []int) {
+	return file_api_advert_proto_rawDescGZIP(), []int{0}
+}
+
+type AdvertSortKey int32
+
+const (
+	AdvertSortKey_ADVERT_SORT_KEY_CREATED_AT AdvertSortKey = 0
+	AdvertSortKey_ADVERT_SORT_KEY_EXPIRED_AT AdvertSortKey = 1
+	AdvertSortKey_ADVERT_SORT_KEY_TITLE      AdvertSortKey = 2
+)
+
+// Enum value maps for AdvertSortKey.
+var (
+	AdvertSortKey_name = map[int32]string{
+		0: "ADVERT_SORT_KEY_CREATED_AT",
+		1: "ADVERT_SORT_KEY_EXPIRED_AT",
+		2: "ADVERT_SORT_KEY_TITLE",
+	}
+	AdvertSortKey_value = map[string]int32{
+		"ADVERT_SORT_KEY_CREATED_AT": 0,
+		"ADVERT_SORT_KEY_EXPIRED_AT": 1,
+		"ADVERT_SORT_KEY_TITLE":      2,
+	}
+)
+
+func (x AdvertSortKey) Enum() *AdvertSortKey {
+	p := new(AdvertSortKey)
+	*p = x
+	return p
+}
+
+func (x AdvertSortKey) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (AdvertSortKey) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_advert_proto_enumTypes[1].Descriptor()
+}
+
+func (AdvertSortKey) Type() protoreflect.EnumType {
+	return &file_api_advert_proto_enumTypes[1]
+}
+
+func (x AdvertSortKey) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use AdvertSortKey.Descriptor instead.
+func (AdvertSortKey) EnumDescriptor() ([]byte, []int) {
+	return file_api_advert_proto_rawDescGZIP(), []int{1}
+}
+
 type AdvertEmpty struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	unknownFields protoimpl.UnknownFields
@@ -59,11 +180,18 @@ func (*AdvertEmpty) Descriptor() ([]byte, []int) {
 }
 
 type AdvertText struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
-	Title         string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
-	TextContent   string                 `protobuf:"bytes,3,opt,name=text_content,json=textContent,proto3" json:"text_content,omitempty"`
-	ExpiredAt     *timestamp.Timestamp   `protobuf:"bytes,4,opt,name=expired_at,json=expiredAt,proto3" json:"expired_at,omitempty"`
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Id          int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Title       string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	TextContent string                 `protobuf:"bytes,3,opt,name=text_content,json=textContent,proto3" json:"text_content,omitempty"`
+	ExpiredAt   *timestamp.Timestamp   `protobuf:"bytes,4,opt,name=expired_at,json=expiredAt,proto3" json:"expired_at,omitempty"`
+	IsBanned    bool                   `protobuf:"varint,5,opt,name=is_banned,json=isBanned,proto3" json:"is_banned,omitempty"`
+	BanReason   string                 `protobuf:"bytes,6,opt,name=ban_reason,json=banReason,proto3" json:"ban_reason,omitempty"`
+	Status      AdvertStatus           `protobuf:"varint,7,opt,name=status,proto3,enum=AdvertStatus" json:"status,omitempty"`
+	Version     int64                  `protobuf:"varint,8,opt,name=version,proto3" json:"version,omitempty"`
+	StartAt     *timestamp.Timestamp   `protobuf:"bytes,9,opt,name=start_at,json=startAt,proto3" json:"start_at,omitempty"`
+	// os из фильтра объявления с названиями из справочника атрибутов
+	Os            []*AttributeValue `protobuf:"bytes,10,rep,name=os,proto3" json:"os,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -126,27 +254,70 @@ func (x *AdvertText) GetExpiredAt() *timestamp.Timestamp {
 	return nil
 }
 
-type GetAdvertIn struct {
+func (x *AdvertText) GetIsBanned() bool {
+	if x != nil {
+		return x.IsBanned
+	}
+	return false
+}
+
+func (x *AdvertText) GetBanReason() string {
+	if x != nil {
+		return x.BanReason
+	}
+	return ""
+}
+
+func (x *AdvertText) GetStatus() AdvertStatus {
+	if x != nil {
+		return x.Status
+	}
+	return AdvertStatus_ADVERT_STATUS_UNSPECIFIED
+}
+
+func (x *AdvertText) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *AdvertText) GetStartAt() *timestamp.Timestamp {
+	if x != nil {
+		return x.StartAt
+	}
+	return nil
+}
+
+func (x *AdvertText) GetOs() []*AttributeValue {
+	if x != nil {
+		return x.Os
+	}
+	return nil
+}
+
+type AttributeValue struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetAdvertIn) Reset() {
-	*x = GetAdvertIn{}
+func (x *AttributeValue) Reset() {
+	*x = AttributeValue{}
 	mi := &file_api_advert_proto_msgTypes[2]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetAdvertIn) String() string {
+func (x *AttributeValue) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetAdvertIn) ProtoMessage() {}
+func (*AttributeValue) ProtoMessage() {}
 
-func (x *GetAdvertIn) ProtoReflect() protoreflect.Message {
+func (x *AttributeValue) ProtoReflect() protoreflect.Message {
 	mi := &file_api_advert_proto_msgTypes[2]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -158,39 +329,46 @@ func (x *GetAdvertIn) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetAdvertIn.ProtoReflect.Descriptor instead.
-func (*GetAdvertIn) Descriptor() ([]byte, []int) {
+// Deprecated: Use AttributeValue.ProtoReflect.Descriptor instead.
+func (*AttributeValue) Descriptor() ([]byte, []int) {
 	return file_api_advert_proto_rawDescGZIP(), []int{2}
 }
 
-func (x *GetAdvertIn) GetId() int64 {
+func (x *AttributeValue) GetId() int64 {
 	if x != nil {
 		return x.Id
 	}
 	return 0
 }
 
-type GetAdvertOut struct {
+func (x *AttributeValue) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type GetAdvertIn struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Advert        *AdvertText            `protobuf:"bytes,1,opt,name=advert,proto3" json:"advert,omitempty"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetAdvertOut) Reset() {
-	*x = GetAdvertOut{}
+func (x *GetAdvertIn) Reset() {
+	*x = GetAdvertIn{}
 	mi := &file_api_advert_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetAdvertOut) String() string {
+func (x *GetAdvertIn) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetAdvertOut) ProtoMessage() {}
+func (*GetAdvertIn) ProtoMessage() {}
 
-func (x *GetAdvertOut) ProtoReflect() protoreflect.Message {
+func (x *GetAdvertIn) ProtoReflect() protoreflect.Message {
 	mi := &file_api_advert_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -202,39 +380,39 @@ func (x *GetAdvertOut) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetAdvertOut.ProtoReflect.Descriptor instead.
-func (*GetAdvertOut) Descriptor() ([]byte, []int) {
+// Deprecated: Use GetAdvertIn.ProtoReflect.Descriptor instead.
+func (*GetAdvertIn) Descriptor() ([]byte, []int) {
 	return file_api_advert_proto_rawDescGZIP(), []int{3}
 }
 
-func (x *GetAdvertOut) GetAdvert() *AdvertText {
+func (x *GetAdvertIn) GetId() int64 {
 	if x != nil {
-		return x.Advert
+		return x.Id
 	}
-	return nil
+	return 0
 }
 
-type GetAdvertsOut struct {
+type GetAdvertOut struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Adverts       []*AdvertText          `protobuf:"bytes,1,rep,name=adverts,proto3" json:"adverts,omitempty"`
+	Advert        *AdvertText            `protobuf:"bytes,1,opt,name=advert,proto3" json:"advert,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetAdvertsOut) Reset() {
-	*x = GetAdvertsOut{}
+func (x *GetAdvertOut) Reset() {
+	*x = GetAdvertOut{}
 	mi := &file_api_advert_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetAdvertsOut) String() string {
+func (x *GetAdvertOut) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetAdvertsOut) ProtoMessage() {}
+func (*GetAdvertOut) ProtoMessage() {}
 
-func (x *GetAdvertsOut) ProtoReflect() protoreflect.Message {
+func (x *GetAdvertOut) ProtoReflect() protoreflect.Message {
 	mi := &file_api_advert_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -246,39 +424,43 @@ func (x *GetAdvertsOut) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetAdvertsOut.ProtoReflect.Descriptor instead.
-func (*GetAdvertsOut) Descriptor() ([]byte, []int) {
+// Deprecated: Use GetAdvertOut.ProtoReflect.Descriptor instead.
+func (*GetAdvertOut) Descriptor() ([]byte, []int) {
 	return file_api_advert_proto_rawDescGZIP(), []int{4}
 }
 
-func (x *GetAdvertsOut) GetAdverts() []*AdvertText {
+func (x *GetAdvertOut) GetAdvert() *AdvertText {
 	if x != nil {
-		return x.Adverts
+		return x.Advert
 	}
 	return nil
 }
 
-type UserFilter struct {
+type GetAdvertsIn struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Os            []int64                `protobuf:"varint,1,rep,packed,name=os,proto3" json:"os,omitempty"`
+	PageSize      int32                  `protobuf:"varint,1,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	PageToken     string                 `protobuf:"bytes,2,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	SortBy        AdvertSortKey          `protobuf:"varint,3,opt,name=sort_by,json=sortBy,proto3,enum=AdvertSortKey" json:"sort_by,omitempty"`
+	Descending    bool                   `protobuf:"varint,4,opt,name=descending,proto3" json:"descending,omitempty"`
+	Statuses      []AdvertStatus         `protobuf:"varint,5,rep,packed,name=statuses,proto3,enum=AdvertStatus" json:"statuses,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UserFilter) Reset() {
-	*x = UserFilter{}
+func (x *GetAdvertsIn) Reset() {
+	*x = GetAdvertsIn{}
 	mi := &file_api_advert_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UserFilter) String() string {
+func (x *GetAdvertsIn) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UserFilter) ProtoMessage() {}
+func (*GetAdvertsIn) ProtoMessage() {}
 
-func (x *UserFilter) ProtoReflect() protoreflect.Message {
+func (x *GetAdvertsIn) ProtoReflect() protoreflect.Message {
 	mi := &file_api_advert_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -290,42 +472,69 @@ func (x *UserFilter) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UserFilter.ProtoReflect.Descriptor instead.
-func (*UserFilter) Descriptor() ([]byte, []int) {
+// Deprecated: Use GetAdvertsIn.ProtoReflect.Descriptor instead.
+func (*GetAdvertsIn) Descriptor() ([]byte, []int) {
 	return file_api_advert_proto_rawDescGZIP(), []int{5}
 }
 
-func (x *UserFilter) GetOs() []int64 {
+func (x *GetAdvertsIn) GetPageSize() int32 {
 	if x != nil {
-		return x.Os
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *GetAdvertsIn) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *GetAdvertsIn) GetSortBy() AdvertSortKey {
+	if x != nil {
+		return x.SortBy
+	}
+	return AdvertSortKey_ADVERT_SORT_KEY_CREATED_AT
+}
+
+func (x *GetAdvertsIn) GetDescending() bool {
+	if x != nil {
+		return x.Descending
+	}
+	return false
+}
+
+func (x *GetAdvertsIn) GetStatuses() []AdvertStatus {
+	if x != nil {
+		return x.Statuses
 	}
 	return nil
 }
 
-type CreateAdvertIn struct {
+type GetAdvertsOut struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Title         string                 `protobuf:"bytes,1,opt,name=title,proto3" json:"title,omitempty"`
-	TextContent   string                 `protobuf:"bytes,2,opt,name=text_content,json=textContent,proto3" json:"text_content,omitempty"`
-	User          *UserFilter            `protobuf:"bytes,3,opt,name=user,proto3" json:"user,omitempty"`
-	ExpiredAt     *timestamp.Timestamp   `protobuf:"bytes,4,opt,name=expired_at,json=expiredAt,proto3" json:"expired_at,omitempty"`
+	Adverts       []*AdvertText          `protobuf:"bytes,1,rep,name=adverts,proto3" json:"adverts,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	TotalCount    int64                  `protobuf:"varint,3,opt,name=total_count,json=totalCount,proto3" json:"total_count,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CreateAdvertIn) Reset() {
-	*x = CreateAdvertIn{}
+func (x *GetAdvertsOut) Reset() {
+	*x = GetAdvertsOut{}
 	mi := &file_api_advert_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CreateAdvertIn) String() string {
+func (x *GetAdvertsOut) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreateAdvertIn) ProtoMessage() {}
+func (*GetAdvertsOut) ProtoMessage() {}
 
-func (x *CreateAdvertIn) ProtoReflect() protoreflect.Message {
+func (x *GetAdvertsOut) ProtoReflect() protoreflect.Message {
 	mi := &file_api_advert_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -337,60 +546,53 @@ func (x *CreateAdvertIn) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreateAdvertIn.ProtoReflect.Descriptor instead.
-func (*CreateAdvertIn) Descriptor() ([]byte, []int) {
+// Deprecated: Use GetAdvertsOut.ProtoReflect.Descriptor instead.
+func (*GetAdvertsOut) Descriptor() ([]byte, []int) {
 	return file_api_advert_proto_rawDescGZIP(), []int{6}
 }
 
-func (x *CreateAdvertIn) GetTitle() string {
+func (x *GetAdvertsOut) GetAdverts() []*AdvertText {
 	if x != nil {
-		return x.Title
+		return x.Adverts
 	}
-	return ""
+	return nil
 }
 
-func (x *CreateAdvertIn) GetTextContent() string {
+func (x *GetAdvertsOut) GetNextPageToken() string {
 	if x != nil {
-		return x.TextContent
+		return x.NextPageToken
 	}
 	return ""
 }
 
-func (x *CreateAdvertIn) GetUser() *UserFilter {
-	if x != nil {
-		return x.User
-	}
-	return nil
-}
-
-func (x *CreateAdvertIn) GetExpiredAt() *timestamp.Timestamp {
+func (x *GetAdvertsOut) GetTotalCount() int64 {
 	if x != nil {
-		return x.ExpiredAt
+		return x.TotalCount
 	}
-	return nil
+	return 0
 }
 
-type CancelAdvertIn struct {
+type UserFilter struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Os            []int64                `protobuf:"varint,1,rep,packed,name=os,proto3" json:"os,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CancelAdvertIn) Reset() {
-	*x = CancelAdvertIn{}
+func (x *UserFilter) Reset() {
+	*x = UserFilter{}
 	mi := &file_api_advert_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CancelAdvertIn) String() string {
+func (x *UserFilter) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CancelAdvertIn) ProtoMessage() {}
+func (*UserFilter) ProtoMessage() {}
 
-func (x *CancelAdvertIn) ProtoReflect() protoreflect.Message {
+func (x *UserFilter) ProtoReflect() protoreflect.Message {
 	mi := &file_api_advert_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -402,39 +604,41 @@ func (x *CancelAdvertIn) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CancelAdvertIn.ProtoReflect.Descriptor instead.
-func (*CancelAdvertIn) Descriptor() ([]byte, []int) {
+// Deprecated: Use UserFilter.ProtoReflect.Descriptor instead.
+func (*UserFilter) Descriptor() ([]byte, []int) {
 	return file_api_advert_proto_rawDescGZIP(), []int{7}
 }
 
-func (x *CancelAdvertIn) GetId() int64 {
+func (x *UserFilter) GetOs() []int64 {
 	if x != nil {
-		return x.Id
+		return x.Os
 	}
-	return 0
+	return nil
 }
 
-type RestoreAdvertIn struct {
+// Атрибуты пользователя, по которым сверяется UserFilter объявления. Пока в фильтре и справочнике
+// атрибутов есть только os; новые атрибуты добавляются сюда вместе с полем UserFilter
+type UserAttributes struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Os            int64                  `protobuf:"varint,1,opt,name=os,proto3" json:"os,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *RestoreAdvertIn) Reset() {
-	*x = RestoreAdvertIn{}
+func (x *UserAttributes) Reset() {
+	*x = UserAttributes{}
 	mi := &file_api_advert_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *RestoreAdvertIn) String() string {
+func (x *UserAttributes) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RestoreAdvertIn) ProtoMessage() {}
+func (*UserAttributes) ProtoMessage() {}
 
-func (x *RestoreAdvertIn) ProtoReflect() protoreflect.Message {
+func (x *UserAttributes) ProtoReflect() protoreflect.Message {
 	mi := &file_api_advert_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -446,42 +650,42 @@ func (x *RestoreAdvertIn) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RestoreAdvertIn.ProtoReflect.Descriptor instead.
-func (*RestoreAdvertIn) Descriptor() ([]byte, []int) {
+// Deprecated: Use UserAttributes.ProtoReflect.Descriptor instead.
+func (*UserAttributes) Descriptor() ([]byte, []int) {
 	return file_api_advert_proto_rawDescGZIP(), []int{8}
 }
 
-func (x *RestoreAdvertIn) GetId() int64 {
+func (x *UserAttributes) GetOs() int64 {
 	if x != nil {
-		return x.Id
+		return x.Os
 	}
 	return 0
 }
 
-type EditAdvertIn struct {
+// Лента отдаётся от новых объявлений к старым; total_count в ответе не считается
+type GetAdvertsForUserIn struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
-	Title         string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
-	TextContent   string                 `protobuf:"bytes,3,opt,name=text_content,json=textContent,proto3" json:"text_content,omitempty"`
-	UserFilter    *UserFilter            `protobuf:"bytes,4,opt,name=user_filter,json=userFilter,proto3" json:"user_filter,omitempty"`
+	User          *UserAttributes        `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
+	PageSize      int32                  `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	PageToken     string                 `protobuf:"bytes,3,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *EditAdvertIn) Reset() {
-	*x = EditAdvertIn{}
+func (x *GetAdvertsForUserIn) Reset() {
+	*x = GetAdvertsForUserIn{}
 	mi := &file_api_advert_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *EditAdvertIn) String() string {
+func (x *GetAdvertsForUserIn) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*EditAdvertIn) ProtoMessage() {}
+func (*GetAdvertsForUserIn) ProtoMessage() {}
 
-func (x *EditAdvertIn) ProtoReflect() protoreflect.Message {
+func (x *GetAdvertsForUserIn) ProtoReflect() protoreflect.Message {
 	mi := &file_api_advert_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -493,107 +697,1091 @@ func (x *EditAdvertIn) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use EditAdvertIn.ProtoReflect.Descriptor instead.
-func (*EditAdvertIn) Descriptor() ([]byte, []int) {
+// Deprecated: Use GetAdvertsForUserIn.ProtoReflect.Descriptor instead.
+func (*GetAdvertsForUserIn) Descriptor() ([]byte, []int) {
 	return file_api_advert_proto_rawDescGZIP(), []int{9}
 }
 
-func (x *EditAdvertIn) GetId() int32 {
+func (x *GetAdvertsForUserIn) GetUser() *UserAttributes {
 	if x != nil {
-		return x.Id
+		return x.User
 	}
-	return 0
+	return nil
 }
 
-func (x *EditAdvertIn) GetTitle() string {
+func (x *GetAdvertsForUserIn) GetPageSize() int32 {
 	if x != nil {
-		return x.Title
+		return x.PageSize
 	}
-	return ""
+	return 0
+}
+
+func (x *GetAdvertsForUserIn) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+type CreateAdvertIn struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Title         string                 `protobuf:"bytes,1,opt,name=title,proto3" json:"title,omitempty"`
+	TextContent   string                 `protobuf:"bytes,2,opt,name=text_content,json=textContent,proto3" json:"text_content,omitempty"`
+	User          *UserFilter            `protobuf:"bytes,3,opt,name=user,proto3" json:"user,omitempty"`
+	ExpiredAt     *timestamp.Timestamp   `protobuf:"bytes,4,opt,name=expired_at,json=expiredAt,proto3" json:"expired_at,omitempty"`
+	StartAt       *timestamp.Timestamp   `protobuf:"bytes,5,opt,name=start_at,json=startAt,proto3" json:"start_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateAdvertIn) Reset() {
+	*x = CreateAdvertIn{}
+	mi := &file_api_advert_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateAdvertIn) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateAdvertIn) ProtoMessage() {}
+
+func (x *CreateAdvertIn) ProtoReflect() protoreflect.Message {
+	mi := &file_api_advert_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateAdvertIn.ProtoReflect.Descriptor instead.
+func (*CreateAdvertIn) Descriptor() ([]byte, []int) {
+	return file_api_advert_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *CreateAdvertIn) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *CreateAdvertIn) GetTextContent() string {
+	if x != nil {
+		return x.TextContent
+	}
+	return ""
+}
+
+func (x *CreateAdvertIn) GetUser() *UserFilter {
+	if x != nil {
+		return x.User
+	}
+	return nil
+}
+
+func (x *CreateAdvertIn) GetExpiredAt() *timestamp.Timestamp {
+	if x != nil {
+		return x.ExpiredAt
+	}
+	return nil
+}
+
+func (x *CreateAdvertIn) GetStartAt() *timestamp.Timestamp {
+	if x != nil {
+		return x.StartAt
+	}
+	return nil
+}
+
+type CancelAdvertIn struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Id              int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	ExpectedVersion int64                  `protobuf:"varint,2,opt,name=expected_version,json=expectedVersion,proto3" json:"expected_version,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *CancelAdvertIn) Reset() {
+	*x = CancelAdvertIn{}
+	mi := &file_api_advert_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelAdvertIn) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelAdvertIn) ProtoMessage() {}
+
+func (x *CancelAdvertIn) ProtoReflect() protoreflect.Message {
+	mi := &file_api_advert_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelAdvertIn.ProtoReflect.Descriptor instead.
+func (*CancelAdvertIn) Descriptor() ([]byte, []int) {
+	return file_api_advert_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *CancelAdvertIn) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *CancelAdvertIn) GetExpectedVersion() int64 {
+	if x != nil {
+		return x.ExpectedVersion
+	}
+	return 0
+}
+
+type RestoreAdvertIn struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Id              int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	ExpectedVersion int64                  `protobuf:"varint,2,opt,name=expected_version,json=expectedVersion,proto3" json:"expected_version,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *RestoreAdvertIn) Reset() {
+	*x = RestoreAdvertIn{}
+	mi := &file_api_advert_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RestoreAdvertIn) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestoreAdvertIn) ProtoMessage() {}
+
+func (x *RestoreAdvertIn) ProtoReflect() protoreflect.Message {
+	mi := &file_api_advert_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RestoreAdvertIn.ProtoReflect.Descriptor instead.
+func (*RestoreAdvertIn) Descriptor() ([]byte, []int) {
+	return file_api_advert_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *RestoreAdvertIn) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *RestoreAdvertIn) GetExpectedVersion() int64 {
+	if x != nil {
+		return x.ExpectedVersion
+	}
+	return 0
+}
+
+type EditAdvertIn struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Id          int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Title       string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	TextContent string                 `protobuf:"bytes,3,opt,name=text_content,json=textContent,proto3" json:"text_content,omitempty"`
+	UserFilter  *UserFilter            `protobuf:"bytes,4,opt,name=user_filter,json=userFilter,proto3" json:"user_filter,omitempty"`
+	// Новый срок проходит тот же лимит срока жизни, что и ExtendAdvert, и попадает в историю продлений
+	ExpiredAt       *timestamp.Timestamp   `protobuf:"bytes,5,opt,name=expired_at,json=expiredAt,proto3" json:"expired_at,omitempty"`
+	UpdateMask      *fieldmaskpb.FieldMask `protobuf:"bytes,6,opt,name=update_mask,json=updateMask,proto3" json:"update_mask,omitempty"`
+	ExpectedVersion int64                  `protobuf:"varint,7,opt,name=expected_version,json=expectedVersion,proto3" json:"expected_version,omitempty"`
+	StartAt         *timestamp.Timestamp   `protobuf:"bytes,8,opt,name=start_at,json=startAt,proto3" json:"start_at,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *EditAdvertIn) Reset() {
+	*x = EditAdvertIn{}
+	mi := &file_api_advert_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EditAdvertIn) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EditAdvertIn) ProtoMessage() {}
+
+func (x *EditAdvertIn) ProtoReflect() protoreflect.Message {
+	mi := &file_api_advert_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EditAdvertIn.ProtoReflect.Descriptor instead.
+func (*EditAdvertIn) Descriptor() ([]byte, []int) {
+	return file_api_advert_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *EditAdvertIn) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *EditAdvertIn) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *EditAdvertIn) GetTextContent() string {
+	if x != nil {
+		return x.TextContent
+	}
+	return ""
+}
+
+func (x *EditAdvertIn) GetUserFilter() *UserFilter {
+	if x != nil {
+		return x.UserFilter
+	}
+	return nil
+}
+
+func (x *EditAdvertIn) GetExpiredAt() *timestamp.Timestamp {
+	if x != nil {
+		return x.ExpiredAt
+	}
+	return nil
+}
+
+func (x *EditAdvertIn) GetUpdateMask() *fieldmaskpb.FieldMask {
+	if x != nil {
+		return x.UpdateMask
+	}
+	return nil
+}
+
+func (x *EditAdvertIn) GetExpectedVersion() int64 {
+	if x != nil {
+		return x.ExpectedVersion
+	}
+	return 0
+}
+
+func (x *EditAdvertIn) GetStartAt() *timestamp.Timestamp {
+	if x != nil {
+		return x.StartAt
+	}
+	return nil
+}
+
+type BanAdvertIn struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Reason        string                 `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BanAdvertIn) Reset() {
+	*x = BanAdvertIn{}
+	mi := &file_api_advert_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BanAdvertIn) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BanAdvertIn) ProtoMessage() {}
+
+func (x *BanAdvertIn) ProtoReflect() protoreflect.Message {
+	mi := &file_api_advert_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BanAdvertIn.ProtoReflect.Descriptor instead.
+func (*BanAdvertIn) Descriptor() ([]byte, []int) {
+	return file_api_advert_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *BanAdvertIn) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *BanAdvertIn) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type UnbanAdvertIn struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnbanAdvertIn) Reset() {
+	*x = UnbanAdvertIn{}
+	mi := &file_api_advert_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnbanAdvertIn) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnbanAdvertIn) ProtoMessage() {}
+
+func (x *UnbanAdvertIn) ProtoReflect() protoreflect.Message {
+	mi := &file_api_advert_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnbanAdvertIn.ProtoReflect.Descriptor instead.
+func (*UnbanAdvertIn) Descriptor() ([]byte, []int) {
+	return file_api_advert_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *UnbanAdvertIn) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type AdvertRevision struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	AdvertId      int64                  `protobuf:"varint,2,opt,name=advert_id,json=advertId,proto3" json:"advert_id,omitempty"`
+	Version       int64                  `protobuf:"varint,3,opt,name=version,proto3" json:"version,omitempty"`
+	Title         string                 `protobuf:"bytes,4,opt,name=title,proto3" json:"title,omitempty"`
+	TextContent   string                 `protobuf:"bytes,5,opt,name=text_content,json=textContent,proto3" json:"text_content,omitempty"`
+	UserFilter    *UserFilter            `protobuf:"bytes,6,opt,name=user_filter,json=userFilter,proto3" json:"user_filter,omitempty"`
+	ExpiredAt     *timestamp.Timestamp   `protobuf:"bytes,7,opt,name=expired_at,json=expiredAt,proto3" json:"expired_at,omitempty"`
+	EditedBy      string                 `protobuf:"bytes,8,opt,name=edited_by,json=editedBy,proto3" json:"edited_by,omitempty"`
+	CreatedAt     *timestamp.Timestamp   `protobuf:"bytes,9,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AdvertRevision) Reset() {
+	*x = AdvertRevision{}
+	mi := &file_api_advert_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AdvertRevision) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AdvertRevision) ProtoMessage() {}
+
+func (x *AdvertRevision) ProtoReflect() protoreflect.Message {
+	mi := &file_api_advert_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
 }
 
-func (x *EditAdvertIn) GetTextContent() string {
+// Deprecated: Use AdvertRevision.ProtoReflect.Descriptor instead.
+func (*AdvertRevision) Descriptor() ([]byte, []int) {
+	return file_api_advert_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *AdvertRevision) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *AdvertRevision) GetAdvertId() int64 {
+	if x != nil {
+		return x.AdvertId
+	}
+	return 0
+}
+
+func (x *AdvertRevision) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *AdvertRevision) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *AdvertRevision) GetTextContent() string {
 	if x != nil {
 		return x.TextContent
 	}
 	return ""
 }
 
-func (x *EditAdvertIn) GetUserFilter() *UserFilter {
+func (x *AdvertRevision) GetUserFilter() *UserFilter {
 	if x != nil {
 		return x.UserFilter
 	}
 	return nil
 }
 
+func (x *AdvertRevision) GetExpiredAt() *timestamp.Timestamp {
+	if x != nil {
+		return x.ExpiredAt
+	}
+	return nil
+}
+
+func (x *AdvertRevision) GetEditedBy() string {
+	if x != nil {
+		return x.EditedBy
+	}
+	return ""
+}
+
+func (x *AdvertRevision) GetCreatedAt() *timestamp.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+type ListAdvertRevisionsIn struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AdvertId      int64                  `protobuf:"varint,1,opt,name=advert_id,json=advertId,proto3" json:"advert_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAdvertRevisionsIn) Reset() {
+	*x = ListAdvertRevisionsIn{}
+	mi := &file_api_advert_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAdvertRevisionsIn) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAdvertRevisionsIn) ProtoMessage() {}
+
+func (x *ListAdvertRevisionsIn) ProtoReflect() protoreflect.Message {
+	mi := &file_api_advert_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAdvertRevisionsIn.ProtoReflect.Descriptor instead.
+func (*ListAdvertRevisionsIn) Descriptor() ([]byte, []int) {
+	return file_api_advert_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *ListAdvertRevisionsIn) GetAdvertId() int64 {
+	if x != nil {
+		return x.AdvertId
+	}
+	return 0
+}
+
+type ListAdvertRevisionsOut struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Revisions     []*AdvertRevision      `protobuf:"bytes,1,rep,name=revisions,proto3" json:"revisions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAdvertRevisionsOut) Reset() {
+	*x = ListAdvertRevisionsOut{}
+	mi := &file_api_advert_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAdvertRevisionsOut) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAdvertRevisionsOut) ProtoMessage() {}
+
+func (x *ListAdvertRevisionsOut) ProtoReflect() protoreflect.Message {
+	mi := &file_api_advert_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAdvertRevisionsOut.ProtoReflect.Descriptor instead.
+func (*ListAdvertRevisionsOut) Descriptor() ([]byte, []int) {
+	return file_api_advert_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *ListAdvertRevisionsOut) GetRevisions() []*AdvertRevision {
+	if x != nil {
+		return x.Revisions
+	}
+	return nil
+}
+
+type RevertAdvertIn struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	AdvertId        int64                  `protobuf:"varint,1,opt,name=advert_id,json=advertId,proto3" json:"advert_id,omitempty"`
+	RevisionId      int64                  `protobuf:"varint,2,opt,name=revision_id,json=revisionId,proto3" json:"revision_id,omitempty"`
+	ExpectedVersion int64                  `protobuf:"varint,3,opt,name=expected_version,json=expectedVersion,proto3" json:"expected_version,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *RevertAdvertIn) Reset() {
+	*x = RevertAdvertIn{}
+	mi := &file_api_advert_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevertAdvertIn) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevertAdvertIn) ProtoMessage() {}
+
+func (x *RevertAdvertIn) ProtoReflect() protoreflect.Message {
+	mi := &file_api_advert_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevertAdvertIn.ProtoReflect.Descriptor instead.
+func (*RevertAdvertIn) Descriptor() ([]byte, []int) {
+	return file_api_advert_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *RevertAdvertIn) GetAdvertId() int64 {
+	if x != nil {
+		return x.AdvertId
+	}
+	return 0
+}
+
+func (x *RevertAdvertIn) GetRevisionId() int64 {
+	if x != nil {
+		return x.RevisionId
+	}
+	return 0
+}
+
+func (x *RevertAdvertIn) GetExpectedVersion() int64 {
+	if x != nil {
+		return x.ExpectedVersion
+	}
+	return 0
+}
+
+type PauseAdvertIn struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Id              int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	ExpectedVersion int64                  `protobuf:"varint,2,opt,name=expected_version,json=expectedVersion,proto3" json:"expected_version,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *PauseAdvertIn) Reset() {
+	*x = PauseAdvertIn{}
+	mi := &file_api_advert_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PauseAdvertIn) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PauseAdvertIn) ProtoMessage() {}
+
+func (x *PauseAdvertIn) ProtoReflect() protoreflect.Message {
+	mi := &file_api_advert_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PauseAdvertIn.ProtoReflect.Descriptor instead.
+func (*PauseAdvertIn) Descriptor() ([]byte, []int) {
+	return file_api_advert_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *PauseAdvertIn) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *PauseAdvertIn) GetExpectedVersion() int64 {
+	if x != nil {
+		return x.ExpectedVersion
+	}
+	return 0
+}
+
+type ResumeAdvertIn struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Id              int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	ExpectedVersion int64                  `protobuf:"varint,2,opt,name=expected_version,json=expectedVersion,proto3" json:"expected_version,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ResumeAdvertIn) Reset() {
+	*x = ResumeAdvertIn{}
+	mi := &file_api_advert_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResumeAdvertIn) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResumeAdvertIn) ProtoMessage() {}
+
+func (x *ResumeAdvertIn) ProtoReflect() protoreflect.Message {
+	mi := &file_api_advert_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResumeAdvertIn.ProtoReflect.Descriptor instead.
+func (*ResumeAdvertIn) Descriptor() ([]byte, []int) {
+	return file_api_advert_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *ResumeAdvertIn) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *ResumeAdvertIn) GetExpectedVersion() int64 {
+	if x != nil {
+		return x.ExpectedVersion
+	}
+	return 0
+}
+
+type ExtendAdvertIn struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Задаётся ровно одно из полей: новый срок или на сколько продлить текущий
+	ExpiredAt       *timestamp.Timestamp `protobuf:"bytes,2,opt,name=expired_at,json=expiredAt,proto3" json:"expired_at,omitempty"`
+	Duration        *duration.Duration   `protobuf:"bytes,3,opt,name=duration,proto3" json:"duration,omitempty"`
+	ExpectedVersion int64                `protobuf:"varint,4,opt,name=expected_version,json=expectedVersion,proto3" json:"expected_version,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ExtendAdvertIn) Reset() {
+	*x = ExtendAdvertIn{}
+	mi := &file_api_advert_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExtendAdvertIn) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExtendAdvertIn) ProtoMessage() {}
+
+func (x *ExtendAdvertIn) ProtoReflect() protoreflect.Message {
+	mi := &file_api_advert_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExtendAdvertIn.ProtoReflect.Descriptor instead.
+func (*ExtendAdvertIn) Descriptor() ([]byte, []int) {
+	return file_api_advert_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *ExtendAdvertIn) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *ExtendAdvertIn) GetExpiredAt() *timestamp.Timestamp {
+	if x != nil {
+		return x.ExpiredAt
+	}
+	return nil
+}
+
+func (x *ExtendAdvertIn) GetDuration() *duration.Duration {
+	if x != nil {
+		return x.Duration
+	}
+	return nil
+}
+
+func (x *ExtendAdvertIn) GetExpectedVersion() int64 {
+	if x != nil {
+		return x.ExpectedVersion
+	}
+	return 0
+}
+
 var File_api_advert_proto protoreflect.FileDescriptor
 
 var file_api_advert_proto_rawDesc = string([]byte{
 	0x0a, 0x10, 0x61, 0x70, 0x69, 0x2f, 0x61, 0x64, 0x76, 0x65, 0x72, 0x74, 0x2e, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x1a, 0x1f, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x62, 0x75, 0x66, 0x2f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x2e, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x22, 0x0d, 0x0a, 0x0b, 0x41, 0x64, 0x76, 0x65, 0x72, 0x74, 0x45, 0x6d, 0x70,
-	0x74, 0x79, 0x22, 0x90, 0x01, 0x0a, 0x0a, 0x41, 0x64, 0x76, 0x65, 0x72, 0x74, 0x54, 0x65, 0x78,
-	0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x02, 0x69,
-	0x64, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x05, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x12, 0x21, 0x0a, 0x0c, 0x74, 0x65, 0x78, 0x74, 0x5f,
-	0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x74,
-	0x65, 0x78, 0x74, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x12, 0x39, 0x0a, 0x0a, 0x65, 0x78,
-	0x70, 0x69, 0x72, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a,
-	0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
-	0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x65, 0x78, 0x70, 0x69,
-	0x72, 0x65, 0x64, 0x41, 0x74, 0x22, 0x1d, 0x0a, 0x0b, 0x47, 0x65, 0x74, 0x41, 0x64, 0x76, 0x65,
-	0x72, 0x74, 0x49, 0x6e, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03,
-	0x52, 0x02, 0x69, 0x64, 0x22, 0x33, 0x0a, 0x0c, 0x47, 0x65, 0x74, 0x41, 0x64, 0x76, 0x65, 0x72,
-	0x74, 0x4f, 0x75, 0x74, 0x12, 0x23, 0x0a, 0x06, 0x61, 0x64, 0x76, 0x65, 0x72, 0x74, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x0b, 0x32, 0x0b, 0x2e, 0x41, 0x64, 0x76, 0x65, 0x72, 0x74, 0x54, 0x65, 0x78,
-	0x74, 0x52, 0x06, 0x61, 0x64, 0x76, 0x65, 0x72, 0x74, 0x22, 0x36, 0x0a, 0x0d, 0x47, 0x65, 0x74,
-	0x41, 0x64, 0x76, 0x65, 0x72, 0x74, 0x73, 0x4f, 0x75, 0x74, 0x12, 0x25, 0x0a, 0x07, 0x61, 0x64,
-	0x76, 0x65, 0x72, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0b, 0x2e, 0x41, 0x64,
-	0x76, 0x65, 0x72, 0x74, 0x54, 0x65, 0x78, 0x74, 0x52, 0x07, 0x61, 0x64, 0x76, 0x65, 0x72, 0x74,
-	0x73, 0x22, 0x1c, 0x0a, 0x0a, 0x55, 0x73, 0x65, 0x72, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x12,
-	0x0e, 0x0a, 0x02, 0x6f, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x03, 0x52, 0x02, 0x6f, 0x73, 0x22,
-	0xa5, 0x01, 0x0a, 0x0e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x41, 0x64, 0x76, 0x65, 0x72, 0x74,
-	0x49, 0x6e, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x05, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x12, 0x21, 0x0a, 0x0c, 0x74, 0x65, 0x78, 0x74,
-	0x5f, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b,
-	0x74, 0x65, 0x78, 0x74, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x12, 0x1f, 0x0a, 0x04, 0x75,
-	0x73, 0x65, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0b, 0x2e, 0x55, 0x73, 0x65, 0x72,
-	0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x52, 0x04, 0x75, 0x73, 0x65, 0x72, 0x12, 0x39, 0x0a, 0x0a,
+	0x74, 0x6f, 0x1a, 0x1e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x62, 0x75, 0x66, 0x2f, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x1a, 0x20, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x62, 0x75, 0x66, 0x2f, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x5f, 0x6d, 0x61, 0x73, 0x6b, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1f, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x0d, 0x0a, 0x0b, 0x41, 0x64, 0x76, 0x65, 0x72, 0x74, 0x45,
+	0x6d, 0x70, 0x74, 0x79, 0x22, 0xe5, 0x02, 0x0a, 0x0a, 0x41, 0x64, 0x76, 0x65, 0x72, 0x74, 0x54,
+	0x65, 0x78, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x02, 0x69, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x05, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x12, 0x21, 0x0a, 0x0c, 0x74, 0x65, 0x78,
+	0x74, 0x5f, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0b, 0x74, 0x65, 0x78, 0x74, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x12, 0x39, 0x0a, 0x0a,
 	0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b,
 	0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
 	0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x65, 0x78,
-	0x70, 0x69, 0x72, 0x65, 0x64, 0x41, 0x74, 0x22, 0x20, 0x0a, 0x0e, 0x43, 0x61, 0x6e, 0x63, 0x65,
-	0x6c, 0x41, 0x64, 0x76, 0x65, 0x72, 0x74, 0x49, 0x6e, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x02, 0x69, 0x64, 0x22, 0x21, 0x0a, 0x0f, 0x52, 0x65, 0x73,
-	0x74, 0x6f, 0x72, 0x65, 0x41, 0x64, 0x76, 0x65, 0x72, 0x74, 0x49, 0x6e, 0x12, 0x0e, 0x0a, 0x02,
-	0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x02, 0x69, 0x64, 0x22, 0x85, 0x01, 0x0a,
-	0x0c, 0x45, 0x64, 0x69, 0x74, 0x41, 0x64, 0x76, 0x65, 0x72, 0x74, 0x49, 0x6e, 0x12, 0x0e, 0x0a,
-	0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x02, 0x69, 0x64, 0x12, 0x14, 0x0a,
-	0x05, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x69,
-	0x74, 0x6c, 0x65, 0x12, 0x21, 0x0a, 0x0c, 0x74, 0x65, 0x78, 0x74, 0x5f, 0x63, 0x6f, 0x6e, 0x74,
-	0x65, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x74, 0x65, 0x78, 0x74, 0x43,
-	0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x12, 0x2c, 0x0a, 0x0b, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x66,
-	0x69, 0x6c, 0x74, 0x65, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0b, 0x2e, 0x55, 0x73,
-	0x65, 0x72, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x52, 0x0a, 0x75, 0x73, 0x65, 0x72, 0x46, 0x69,
-	0x6c, 0x74, 0x65, 0x72, 0x32, 0xab, 0x02, 0x0a, 0x0d, 0x41, 0x64, 0x76, 0x65, 0x72, 0x74, 0x53,
-	0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x2a, 0x0a, 0x09, 0x47, 0x65, 0x74, 0x41, 0x64, 0x76,
-	0x65, 0x72, 0x74, 0x12, 0x0c, 0x2e, 0x47, 0x65, 0x74, 0x41, 0x64, 0x76, 0x65, 0x72, 0x74, 0x49,
-	0x6e, 0x1a, 0x0d, 0x2e, 0x47, 0x65, 0x74, 0x41, 0x64, 0x76, 0x65, 0x72, 0x74, 0x4f, 0x75, 0x74,
-	0x22, 0x00, 0x12, 0x2c, 0x0a, 0x0a, 0x47, 0x65, 0x74, 0x41, 0x64, 0x76, 0x65, 0x72, 0x74, 0x73,
-	0x12, 0x0c, 0x2e, 0x41, 0x64, 0x76, 0x65, 0x72, 0x74, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x0e,
-	0x2e, 0x47, 0x65, 0x74, 0x41, 0x64, 0x76, 0x65, 0x72, 0x74, 0x73, 0x4f, 0x75, 0x74, 0x22, 0x00,
-	0x12, 0x2f, 0x0a, 0x0c, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x41, 0x64, 0x76, 0x65, 0x72, 0x74,
-	0x12, 0x0f, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x41, 0x64, 0x76, 0x65, 0x72, 0x74, 0x49,
+	0x70, 0x69, 0x72, 0x65, 0x64, 0x41, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x69, 0x73, 0x5f, 0x62, 0x61,
+	0x6e, 0x6e, 0x65, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x69, 0x73, 0x42, 0x61,
+	0x6e, 0x6e, 0x65, 0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x62, 0x61, 0x6e, 0x5f, 0x72, 0x65, 0x61, 0x73,
+	0x6f, 0x6e, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x62, 0x61, 0x6e, 0x52, 0x65, 0x61,
+	0x73, 0x6f, 0x6e, 0x12, 0x25, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x07, 0x20,
+	0x01, 0x28, 0x0e, 0x32, 0x0d, 0x2e, 0x41, 0x64, 0x76, 0x65, 0x72, 0x74, 0x53, 0x74, 0x61, 0x74,
+	0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65,
+	0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x08, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x76, 0x65, 0x72,
+	0x73, 0x69, 0x6f, 0x6e, 0x12, 0x35, 0x0a, 0x08, 0x73, 0x74, 0x61, 0x72, 0x74, 0x5f, 0x61, 0x74,
+	0x18, 0x09, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61,
+	0x6d, 0x70, 0x52, 0x07, 0x73, 0x74, 0x61, 0x72, 0x74, 0x41, 0x74, 0x12, 0x1f, 0x0a, 0x02, 0x6f,
+	0x73, 0x18, 0x0a, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x41, 0x74, 0x74, 0x72, 0x69, 0x62,
+	0x75, 0x74, 0x65, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x02, 0x6f, 0x73, 0x22, 0x34, 0x0a, 0x0e,
+	0x41, 0x74, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x65, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x0e,
+	0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x02, 0x69, 0x64, 0x12, 0x12,
+	0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61,
+	0x6d, 0x65, 0x22, 0x1d, 0x0a, 0x0b, 0x47, 0x65, 0x74, 0x41, 0x64, 0x76, 0x65, 0x72, 0x74, 0x49,
+	0x6e, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x02, 0x69,
+	0x64, 0x22, 0x33, 0x0a, 0x0c, 0x47, 0x65, 0x74, 0x41, 0x64, 0x76, 0x65, 0x72, 0x74, 0x4f, 0x75,
+	0x74, 0x12, 0x23, 0x0a, 0x06, 0x61, 0x64, 0x76, 0x65, 0x72, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x0b, 0x2e, 0x41, 0x64, 0x76, 0x65, 0x72, 0x74, 0x54, 0x65, 0x78, 0x74, 0x52, 0x06,
+	0x61, 0x64, 0x76, 0x65, 0x72, 0x74, 0x22, 0xbe, 0x01, 0x0a, 0x0c, 0x47, 0x65, 0x74, 0x41, 0x64,
+	0x76, 0x65, 0x72, 0x74, 0x73, 0x49, 0x6e, 0x12, 0x1b, 0x0a, 0x09, 0x70, 0x61, 0x67, 0x65, 0x5f,
+	0x73, 0x69, 0x7a, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x70, 0x61, 0x67, 0x65,
+	0x53, 0x69, 0x7a, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x74, 0x6f, 0x6b,
+	0x65, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x70, 0x61, 0x67, 0x65, 0x54, 0x6f,
+	0x6b, 0x65, 0x6e, 0x12, 0x27, 0x0a, 0x07, 0x73, 0x6f, 0x72, 0x74, 0x5f, 0x62, 0x79, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x0e, 0x32, 0x0e, 0x2e, 0x41, 0x64, 0x76, 0x65, 0x72, 0x74, 0x53, 0x6f, 0x72,
+	0x74, 0x4b, 0x65, 0x79, 0x52, 0x06, 0x73, 0x6f, 0x72, 0x74, 0x42, 0x79, 0x12, 0x1e, 0x0a, 0x0a,
+	0x64, 0x65, 0x73, 0x63, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x0a, 0x64, 0x65, 0x73, 0x63, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x12, 0x29, 0x0a, 0x08,
+	0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x65, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0e, 0x32, 0x0d,
+	0x2e, 0x41, 0x64, 0x76, 0x65, 0x72, 0x74, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x08, 0x73,
+	0x74, 0x61, 0x74, 0x75, 0x73, 0x65, 0x73, 0x22, 0x7f, 0x0a, 0x0d, 0x47, 0x65, 0x74, 0x41, 0x64,
+	0x76, 0x65, 0x72, 0x74, 0x73, 0x4f, 0x75, 0x74, 0x12, 0x25, 0x0a, 0x07, 0x61, 0x64, 0x76, 0x65,
+	0x72, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0b, 0x2e, 0x41, 0x64, 0x76, 0x65,
+	0x72, 0x74, 0x54, 0x65, 0x78, 0x74, 0x52, 0x07, 0x61, 0x64, 0x76, 0x65, 0x72, 0x74, 0x73, 0x12,
+	0x26, 0x0a, 0x0f, 0x6e, 0x65, 0x78, 0x74, 0x5f, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x74, 0x6f, 0x6b,
+	0x65, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x6e, 0x65, 0x78, 0x74, 0x50, 0x61,
+	0x67, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x1f, 0x0a, 0x0b, 0x74, 0x6f, 0x74, 0x61, 0x6c,
+	0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x74, 0x6f,
+	0x74, 0x61, 0x6c, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x22, 0x1c, 0x0a, 0x0a, 0x55, 0x73, 0x65, 0x72,
+	0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x12, 0x0e, 0x0a, 0x02, 0x6f, 0x73, 0x18, 0x01, 0x20, 0x03,
+	0x28, 0x03, 0x52, 0x02, 0x6f, 0x73, 0x22, 0x20, 0x0a, 0x0e, 0x55, 0x73, 0x65, 0x72, 0x41, 0x74,
+	0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x65, 0x73, 0x12, 0x0e, 0x0a, 0x02, 0x6f, 0x73, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x02, 0x6f, 0x73, 0x22, 0x76, 0x0a, 0x13, 0x47, 0x65, 0x74, 0x41,
+	0x64, 0x76, 0x65, 0x72, 0x74, 0x73, 0x46, 0x6f, 0x72, 0x55, 0x73, 0x65, 0x72, 0x49, 0x6e, 0x12,
+	0x23, 0x0a, 0x04, 0x75, 0x73, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e,
+	0x55, 0x73, 0x65, 0x72, 0x41, 0x74, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x65, 0x73, 0x52, 0x04,
+	0x75, 0x73, 0x65, 0x72, 0x12, 0x1b, 0x0a, 0x09, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x73, 0x69, 0x7a,
+	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x70, 0x61, 0x67, 0x65, 0x53, 0x69, 0x7a,
+	0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x70, 0x61, 0x67, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e,
+	0x22, 0xdc, 0x01, 0x0a, 0x0e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x41, 0x64, 0x76, 0x65, 0x72,
+	0x74, 0x49, 0x6e, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x05, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x12, 0x21, 0x0a, 0x0c, 0x74, 0x65, 0x78,
+	0x74, 0x5f, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0b, 0x74, 0x65, 0x78, 0x74, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x12, 0x1f, 0x0a, 0x04,
+	0x75, 0x73, 0x65, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0b, 0x2e, 0x55, 0x73, 0x65,
+	0x72, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x52, 0x04, 0x75, 0x73, 0x65, 0x72, 0x12, 0x39, 0x0a,
+	0x0a, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x65,
+	0x78, 0x70, 0x69, 0x72, 0x65, 0x64, 0x41, 0x74, 0x12, 0x35, 0x0a, 0x08, 0x73, 0x74, 0x61, 0x72,
+	0x74, 0x5f, 0x61, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f,
+	0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d,
+	0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x07, 0x73, 0x74, 0x61, 0x72, 0x74, 0x41, 0x74, 0x22,
+	0x4b, 0x0a, 0x0e, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x41, 0x64, 0x76, 0x65, 0x72, 0x74, 0x49,
+	0x6e, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x02, 0x69,
+	0x64, 0x12, 0x29, 0x0a, 0x10, 0x65, 0x78, 0x70, 0x65, 0x63, 0x74, 0x65, 0x64, 0x5f, 0x76, 0x65,
+	0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0f, 0x65, 0x78, 0x70,
+	0x65, 0x63, 0x74, 0x65, 0x64, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x22, 0x4c, 0x0a, 0x0f,
+	0x52, 0x65, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x41, 0x64, 0x76, 0x65, 0x72, 0x74, 0x49, 0x6e, 0x12,
+	0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x02, 0x69, 0x64, 0x12,
+	0x29, 0x0a, 0x10, 0x65, 0x78, 0x70, 0x65, 0x63, 0x74, 0x65, 0x64, 0x5f, 0x76, 0x65, 0x72, 0x73,
+	0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0f, 0x65, 0x78, 0x70, 0x65, 0x63,
+	0x74, 0x65, 0x64, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x22, 0xdf, 0x02, 0x0a, 0x0c, 0x45,
+	0x64, 0x69, 0x74, 0x41, 0x64, 0x76, 0x65, 0x72, 0x74, 0x49, 0x6e, 0x12, 0x0e, 0x0a, 0x02, 0x69,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x02, 0x69, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x74,
+	0x69, 0x74, 0x6c, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x69, 0x74, 0x6c,
+	0x65, 0x12, 0x21, 0x0a, 0x0c, 0x74, 0x65, 0x78, 0x74, 0x5f, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e,
+	0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x74, 0x65, 0x78, 0x74, 0x43, 0x6f, 0x6e,
+	0x74, 0x65, 0x6e, 0x74, 0x12, 0x2c, 0x0a, 0x0b, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x66, 0x69, 0x6c,
+	0x74, 0x65, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0b, 0x2e, 0x55, 0x73, 0x65, 0x72,
+	0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x52, 0x0a, 0x75, 0x73, 0x65, 0x72, 0x46, 0x69, 0x6c, 0x74,
+	0x65, 0x72, 0x12, 0x39, 0x0a, 0x0a, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x64, 0x5f, 0x61, 0x74,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61,
+	0x6d, 0x70, 0x52, 0x09, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x64, 0x41, 0x74, 0x12, 0x3b, 0x0a,
+	0x0b, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x5f, 0x6d, 0x61, 0x73, 0x6b, 0x18, 0x06, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x4d, 0x61, 0x73, 0x6b, 0x52, 0x0a,
+	0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x4d, 0x61, 0x73, 0x6b, 0x12, 0x29, 0x0a, 0x10, 0x65, 0x78,
+	0x70, 0x65, 0x63, 0x74, 0x65, 0x64, 0x5f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x07,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x0f, 0x65, 0x78, 0x70, 0x65, 0x63, 0x74, 0x65, 0x64, 0x56, 0x65,
+	0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x35, 0x0a, 0x08, 0x73, 0x74, 0x61, 0x72, 0x74, 0x5f, 0x61,
+	0x74, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74,
+	0x61, 0x6d, 0x70, 0x52, 0x07, 0x73, 0x74, 0x61, 0x72, 0x74, 0x41, 0x74, 0x22, 0x35, 0x0a, 0x0b,
+	0x42, 0x61, 0x6e, 0x41, 0x64, 0x76, 0x65, 0x72, 0x74, 0x49, 0x6e, 0x12, 0x0e, 0x0a, 0x02, 0x69,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x02, 0x69, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x72,
+	0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x61,
+	0x73, 0x6f, 0x6e, 0x22, 0x1f, 0x0a, 0x0d, 0x55, 0x6e, 0x62, 0x61, 0x6e, 0x41, 0x64, 0x76, 0x65,
+	0x72, 0x74, 0x49, 0x6e, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x02, 0x69, 0x64, 0x22, 0xd1, 0x02, 0x0a, 0x0e, 0x41, 0x64, 0x76, 0x65, 0x72, 0x74, 0x52,
+	0x65, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x02, 0x69, 0x64, 0x12, 0x1b, 0x0a, 0x09, 0x61, 0x64, 0x76, 0x65, 0x72,
+	0x74, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x61, 0x64, 0x76, 0x65,
+	0x72, 0x74, 0x49, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x14,
+	0x0a, 0x05, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74,
+	0x69, 0x74, 0x6c, 0x65, 0x12, 0x21, 0x0a, 0x0c, 0x74, 0x65, 0x78, 0x74, 0x5f, 0x63, 0x6f, 0x6e,
+	0x74, 0x65, 0x6e, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x74, 0x65, 0x78, 0x74,
+	0x43, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x12, 0x2c, 0x0a, 0x0b, 0x75, 0x73, 0x65, 0x72, 0x5f,
+	0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0b, 0x2e, 0x55,
+	0x73, 0x65, 0x72, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x52, 0x0a, 0x75, 0x73, 0x65, 0x72, 0x46,
+	0x69, 0x6c, 0x74, 0x65, 0x72, 0x12, 0x39, 0x0a, 0x0a, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x64,
+	0x5f, 0x61, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
+	0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65,
+	0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x64, 0x41, 0x74,
+	0x12, 0x1b, 0x0a, 0x09, 0x65, 0x64, 0x69, 0x74, 0x65, 0x64, 0x5f, 0x62, 0x79, 0x18, 0x08, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x08, 0x65, 0x64, 0x69, 0x74, 0x65, 0x64, 0x42, 0x79, 0x12, 0x39, 0x0a,
+	0x0a, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x09, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x63,
+	0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x22, 0x34, 0x0a, 0x15, 0x4c, 0x69, 0x73, 0x74,
+	0x41, 0x64, 0x76, 0x65, 0x72, 0x74, 0x52, 0x65, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x49,
+	0x6e, 0x12, 0x1b, 0x0a, 0x09, 0x61, 0x64, 0x76, 0x65, 0x72, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x61, 0x64, 0x76, 0x65, 0x72, 0x74, 0x49, 0x64, 0x22, 0x47,
+	0x0a, 0x16, 0x4c, 0x69, 0x73, 0x74, 0x41, 0x64, 0x76, 0x65, 0x72, 0x74, 0x52, 0x65, 0x76, 0x69,
+	0x73, 0x69, 0x6f, 0x6e, 0x73, 0x4f, 0x75, 0x74, 0x12, 0x2d, 0x0a, 0x09, 0x72, 0x65, 0x76, 0x69,
+	0x73, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x41, 0x64,
+	0x76, 0x65, 0x72, 0x74, 0x52, 0x65, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x09, 0x72, 0x65,
+	0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0x79, 0x0a, 0x0e, 0x52, 0x65, 0x76, 0x65, 0x72,
+	0x74, 0x41, 0x64, 0x76, 0x65, 0x72, 0x74, 0x49, 0x6e, 0x12, 0x1b, 0x0a, 0x09, 0x61, 0x64, 0x76,
+	0x65, 0x72, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x61, 0x64,
+	0x76, 0x65, 0x72, 0x74, 0x49, 0x64, 0x12, 0x1f, 0x0a, 0x0b, 0x72, 0x65, 0x76, 0x69, 0x73, 0x69,
+	0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x72, 0x65, 0x76,
+	0x69, 0x73, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x12, 0x29, 0x0a, 0x10, 0x65, 0x78, 0x70, 0x65, 0x63,
+	0x74, 0x65, 0x64, 0x5f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x0f, 0x65, 0x78, 0x70, 0x65, 0x63, 0x74, 0x65, 0x64, 0x56, 0x65, 0x72, 0x73, 0x69,
+	0x6f, 0x6e, 0x22, 0x4a, 0x0a, 0x0d, 0x50, 0x61, 0x75, 0x73, 0x65, 0x41, 0x64, 0x76, 0x65, 0x72,
+	0x74, 0x49, 0x6e, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x02, 0x69, 0x64, 0x12, 0x29, 0x0a, 0x10, 0x65, 0x78, 0x70, 0x65, 0x63, 0x74, 0x65, 0x64, 0x5f,
+	0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0f, 0x65,
+	0x78, 0x70, 0x65, 0x63, 0x74, 0x65, 0x64, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x22, 0x4b,
+	0x0a, 0x0e, 0x52, 0x65, 0x73, 0x75, 0x6d, 0x65, 0x41, 0x64, 0x76, 0x65, 0x72, 0x74, 0x49, 0x6e,
+	0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x02, 0x69, 0x64,
+	0x12, 0x29, 0x0a, 0x10, 0x65, 0x78, 0x70, 0x65, 0x63, 0x74, 0x65, 0x64, 0x5f, 0x76, 0x65, 0x72,
+	0x73, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0f, 0x65, 0x78, 0x70, 0x65,
+	0x63, 0x74, 0x65, 0x64, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x22, 0xbd, 0x01, 0x0a, 0x0e,
+	0x45, 0x78, 0x74, 0x65, 0x6e, 0x64, 0x41, 0x64, 0x76, 0x65, 0x72, 0x74, 0x49, 0x6e, 0x12, 0x0e,
+	0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x02, 0x69, 0x64, 0x12, 0x39,
+	0x0a, 0x0a, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09,
+	0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x64, 0x41, 0x74, 0x12, 0x35, 0x0a, 0x08, 0x64, 0x75, 0x72,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75,
+	0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x08, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x12, 0x29, 0x0a, 0x10, 0x65, 0x78, 0x70, 0x65, 0x63, 0x74, 0x65, 0x64, 0x5f, 0x76, 0x65, 0x72,
+	0x73, 0x69, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0f, 0x65, 0x78, 0x70, 0x65,
+	0x63, 0x74, 0x65, 0x64, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x2a, 0xa6, 0x02, 0x0a, 0x0c,
+	0x41, 0x64, 0x76, 0x65, 0x72, 0x74, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x1d, 0x0a, 0x19,
+	0x41, 0x44, 0x56, 0x45, 0x52, 0x54, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53, 0x5f, 0x55, 0x4e,
+	0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x17, 0x0a, 0x13, 0x41,
+	0x44, 0x56, 0x45, 0x52, 0x54, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53, 0x5f, 0x44, 0x52, 0x41,
+	0x46, 0x54, 0x10, 0x01, 0x12, 0x20, 0x0a, 0x1c, 0x41, 0x44, 0x56, 0x45, 0x52, 0x54, 0x5f, 0x53,
+	0x54, 0x41, 0x54, 0x55, 0x53, 0x5f, 0x50, 0x45, 0x4e, 0x44, 0x49, 0x4e, 0x47, 0x5f, 0x52, 0x45,
+	0x56, 0x49, 0x45, 0x57, 0x10, 0x02, 0x12, 0x18, 0x0a, 0x14, 0x41, 0x44, 0x56, 0x45, 0x52, 0x54,
+	0x5f, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53, 0x5f, 0x41, 0x43, 0x54, 0x49, 0x56, 0x45, 0x10, 0x03,
+	0x12, 0x18, 0x0a, 0x14, 0x41, 0x44, 0x56, 0x45, 0x52, 0x54, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x55,
+	0x53, 0x5f, 0x50, 0x41, 0x55, 0x53, 0x45, 0x44, 0x10, 0x04, 0x12, 0x1a, 0x0a, 0x16, 0x41, 0x44,
+	0x56, 0x45, 0x52, 0x54, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53, 0x5f, 0x43, 0x41, 0x4e, 0x43,
+	0x45, 0x4c, 0x45, 0x44, 0x10, 0x05, 0x12, 0x18, 0x0a, 0x14, 0x41, 0x44, 0x56, 0x45, 0x52, 0x54,
+	0x5f, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53, 0x5f, 0x42, 0x41, 0x4e, 0x4e, 0x45, 0x44, 0x10, 0x06,
+	0x12, 0x19, 0x0a, 0x15, 0x41, 0x44, 0x56, 0x45, 0x52, 0x54, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x55,
+	0x53, 0x5f, 0x45, 0x58, 0x50, 0x49, 0x52, 0x45, 0x44, 0x10, 0x07, 0x12, 0x1a, 0x0a, 0x16, 0x41,
+	0x44, 0x56, 0x45, 0x52, 0x54, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53, 0x5f, 0x41, 0x52, 0x43,
+	0x48, 0x49, 0x56, 0x45, 0x44, 0x10, 0x08, 0x12, 0x1b, 0x0a, 0x17, 0x41, 0x44, 0x56, 0x45, 0x52,
+	0x54, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53, 0x5f, 0x53, 0x43, 0x48, 0x45, 0x44, 0x55, 0x4c,
+	0x45, 0x44, 0x10, 0x09, 0x2a, 0x6a, 0x0a, 0x0d, 0x41, 0x64, 0x76, 0x65, 0x72, 0x74, 0x53, 0x6f,
+	0x72, 0x74, 0x4b, 0x65, 0x79, 0x12, 0x1e, 0x0a, 0x1a, 0x41, 0x44, 0x56, 0x45, 0x52, 0x54, 0x5f,
+	0x53, 0x4f, 0x52, 0x54, 0x5f, 0x4b, 0x45, 0x59, 0x5f, 0x43, 0x52, 0x45, 0x41, 0x54, 0x45, 0x44,
+	0x5f, 0x41, 0x54, 0x10, 0x00, 0x12, 0x1e, 0x0a, 0x1a, 0x41, 0x44, 0x56, 0x45, 0x52, 0x54, 0x5f,
+	0x53, 0x4f, 0x52, 0x54, 0x5f, 0x4b, 0x45, 0x59, 0x5f, 0x45, 0x58, 0x50, 0x49, 0x52, 0x45, 0x44,
+	0x5f, 0x41, 0x54, 0x10, 0x01, 0x12, 0x19, 0x0a, 0x15, 0x41, 0x44, 0x56, 0x45, 0x52, 0x54, 0x5f,
+	0x53, 0x4f, 0x52, 0x54, 0x5f, 0x4b, 0x45, 0x59, 0x5f, 0x54, 0x49, 0x54, 0x4c, 0x45, 0x10, 0x02,
+	0x32, 0xcf, 0x05, 0x0a, 0x0d, 0x41, 0x64, 0x76, 0x65, 0x72, 0x74, 0x53, 0x65, 0x72, 0x76, 0x69,
+	0x63, 0x65, 0x12, 0x2a, 0x0a, 0x09, 0x47, 0x65, 0x74, 0x41, 0x64, 0x76, 0x65, 0x72, 0x74, 0x12,
+	0x0c, 0x2e, 0x47, 0x65, 0x74, 0x41, 0x64, 0x76, 0x65, 0x72, 0x74, 0x49, 0x6e, 0x1a, 0x0d, 0x2e,
+	0x47, 0x65, 0x74, 0x41, 0x64, 0x76, 0x65, 0x72, 0x74, 0x4f, 0x75, 0x74, 0x22, 0x00, 0x12, 0x2d,
+	0x0a, 0x0a, 0x47, 0x65, 0x74, 0x41, 0x64, 0x76, 0x65, 0x72, 0x74, 0x73, 0x12, 0x0d, 0x2e, 0x47,
+	0x65, 0x74, 0x41, 0x64, 0x76, 0x65, 0x72, 0x74, 0x73, 0x49, 0x6e, 0x1a, 0x0e, 0x2e, 0x47, 0x65,
+	0x74, 0x41, 0x64, 0x76, 0x65, 0x72, 0x74, 0x73, 0x4f, 0x75, 0x74, 0x22, 0x00, 0x12, 0x3b, 0x0a,
+	0x11, 0x47, 0x65, 0x74, 0x41, 0x64, 0x76, 0x65, 0x72, 0x74, 0x73, 0x46, 0x6f, 0x72, 0x55, 0x73,
+	0x65, 0x72, 0x12, 0x14, 0x2e, 0x47, 0x65, 0x74, 0x41, 0x64, 0x76, 0x65, 0x72, 0x74, 0x73, 0x46,
+	0x6f, 0x72, 0x55, 0x73, 0x65, 0x72, 0x49, 0x6e, 0x1a, 0x0e, 0x2e, 0x47, 0x65, 0x74, 0x41, 0x64,
+	0x76, 0x65, 0x72, 0x74, 0x73, 0x4f, 0x75, 0x74, 0x22, 0x00, 0x12, 0x2f, 0x0a, 0x0c, 0x43, 0x72,
+	0x65, 0x61, 0x74, 0x65, 0x41, 0x64, 0x76, 0x65, 0x72, 0x74, 0x12, 0x0f, 0x2e, 0x43, 0x72, 0x65,
+	0x61, 0x74, 0x65, 0x41, 0x64, 0x76, 0x65, 0x72, 0x74, 0x49, 0x6e, 0x1a, 0x0c, 0x2e, 0x41, 0x64,
+	0x76, 0x65, 0x72, 0x74, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x00, 0x12, 0x2f, 0x0a, 0x0c, 0x43,
+	0x61, 0x6e, 0x63, 0x65, 0x6c, 0x41, 0x64, 0x76, 0x65, 0x72, 0x74, 0x12, 0x0f, 0x2e, 0x43, 0x61,
+	0x6e, 0x63, 0x65, 0x6c, 0x41, 0x64, 0x76, 0x65, 0x72, 0x74, 0x49, 0x6e, 0x1a, 0x0c, 0x2e, 0x41,
+	0x64, 0x76, 0x65, 0x72, 0x74, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x00, 0x12, 0x31, 0x0a, 0x0d,
+	0x52, 0x65, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x41, 0x64, 0x76, 0x65, 0x72, 0x74, 0x12, 0x10, 0x2e,
+	0x52, 0x65, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x41, 0x64, 0x76, 0x65, 0x72, 0x74, 0x49, 0x6e, 0x1a,
+	0x0c, 0x2e, 0x41, 0x64, 0x76, 0x65, 0x72, 0x74, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x00, 0x12,
+	0x2b, 0x0a, 0x0a, 0x45, 0x64, 0x69, 0x74, 0x41, 0x64, 0x76, 0x65, 0x72, 0x74, 0x12, 0x0d, 0x2e,
+	0x45, 0x64, 0x69, 0x74, 0x41, 0x64, 0x76, 0x65, 0x72, 0x74, 0x49, 0x6e, 0x1a, 0x0c, 0x2e, 0x41,
+	0x64, 0x76, 0x65, 0x72, 0x74, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x00, 0x12, 0x29, 0x0a, 0x09,
+	0x42, 0x61, 0x6e, 0x41, 0x64, 0x76, 0x65, 0x72, 0x74, 0x12, 0x0c, 0x2e, 0x42, 0x61, 0x6e, 0x41,
+	0x64, 0x76, 0x65, 0x72, 0x74, 0x49, 0x6e, 0x1a, 0x0c, 0x2e, 0x41, 0x64, 0x76, 0x65, 0x72, 0x74,
+	0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x00, 0x12, 0x2d, 0x0a, 0x0b, 0x55, 0x6e, 0x62, 0x61, 0x6e,
+	0x41, 0x64, 0x76, 0x65, 0x72, 0x74, 0x12, 0x0e, 0x2e, 0x55, 0x6e, 0x62, 0x61, 0x6e, 0x41, 0x64,
+	0x76, 0x65, 0x72, 0x74, 0x49, 0x6e, 0x1a, 0x0c, 0x2e, 0x41, 0x64, 0x76, 0x65, 0x72, 0x74, 0x45,
+	0x6d, 0x70, 0x74, 0x79, 0x22, 0x00, 0x12, 0x48, 0x0a, 0x13, 0x4c, 0x69, 0x73, 0x74, 0x41, 0x64,
+	0x76, 0x65, 0x72, 0x74, 0x52, 0x65, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x16, 0x2e,
+	0x4c, 0x69, 0x73, 0x74, 0x41, 0x64, 0x76, 0x65, 0x72, 0x74, 0x52, 0x65, 0x76, 0x69, 0x73, 0x69,
+	0x6f, 0x6e, 0x73, 0x49, 0x6e, 0x1a, 0x17, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x41, 0x64, 0x76, 0x65,
+	0x72, 0x74, 0x52, 0x65, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x4f, 0x75, 0x74, 0x22, 0x00,
+	0x12, 0x2f, 0x0a, 0x0c, 0x52, 0x65, 0x76, 0x65, 0x72, 0x74, 0x41, 0x64, 0x76, 0x65, 0x72, 0x74,
+	0x12, 0x0f, 0x2e, 0x52, 0x65, 0x76, 0x65, 0x72, 0x74, 0x41, 0x64, 0x76, 0x65, 0x72, 0x74, 0x49,
 	0x6e, 0x1a, 0x0c, 0x2e, 0x41, 0x64, 0x76, 0x65, 0x72, 0x74, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22,
-	0x00, 0x12, 0x2f, 0x0a, 0x0c, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x41, 0x64, 0x76, 0x65, 0x72,
-	0x74, 0x12, 0x0f, 0x2e, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x41, 0x64, 0x76, 0x65, 0x72, 0x74,
-	0x49, 0x6e, 0x1a, 0x0c, 0x2e, 0x41, 0x64, 0x76, 0x65, 0x72, 0x74, 0x45, 0x6d, 0x70, 0x74, 0x79,
-	0x22, 0x00, 0x12, 0x31, 0x0a, 0x0d, 0x52, 0x65, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x41, 0x64, 0x76,
-	0x65, 0x72, 0x74, 0x12, 0x10, 0x2e, 0x52, 0x65, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x41, 0x64, 0x76,
-	0x65, 0x72, 0x74, 0x49, 0x6e, 0x1a, 0x0c, 0x2e, 0x41, 0x64, 0x76, 0x65, 0x72, 0x74, 0x45, 0x6d,
-	0x70, 0x74, 0x79, 0x22, 0x00, 0x12, 0x2b, 0x0a, 0x0a, 0x45, 0x64, 0x69, 0x74, 0x41, 0x64, 0x76,
-	0x65, 0x72, 0x74, 0x12, 0x0d, 0x2e, 0x45, 0x64, 0x69, 0x74, 0x41, 0x64, 0x76, 0x65, 0x72, 0x74,
+	0x00, 0x12, 0x2d, 0x0a, 0x0b, 0x50, 0x61, 0x75, 0x73, 0x65, 0x41, 0x64, 0x76, 0x65, 0x72, 0x74,
+	0x12, 0x0e, 0x2e, 0x50, 0x61, 0x75, 0x73, 0x65, 0x41, 0x64, 0x76, 0x65, 0x72, 0x74, 0x49, 0x6e,
+	0x1a, 0x0c, 0x2e, 0x41, 0x64, 0x76, 0x65, 0x72, 0x74, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x00,
+	0x12, 0x2f, 0x0a, 0x0c, 0x52, 0x65, 0x73, 0x75, 0x6d, 0x65, 0x41, 0x64, 0x76, 0x65, 0x72, 0x74,
+	0x12, 0x0f, 0x2e, 0x52, 0x65, 0x73, 0x75, 0x6d, 0x65, 0x41, 0x64, 0x76, 0x65, 0x72, 0x74, 0x49,
+	0x6e, 0x1a, 0x0c, 0x2e, 0x41, 0x64, 0x76, 0x65, 0x72, 0x74, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22,
+	0x00, 0x12, 0x2f, 0x0a, 0x0c, 0x45, 0x78, 0x74, 0x65, 0x6e, 0x64, 0x41, 0x64, 0x76, 0x65, 0x72,
+	0x74, 0x12, 0x0f, 0x2e, 0x45, 0x78, 0x74, 0x65, 0x6e, 0x64, 0x41, 0x64, 0x76, 0x65, 0x72, 0x74,
 	0x49, 0x6e, 0x1a, 0x0c, 0x2e, 0x41, 0x64, 0x76, 0x65, 0x72, 0x74, 0x45, 0x6d, 0x70, 0x74, 0x79,
 	0x22, 0x00, 0x42, 0x0c, 0x5a, 0x0a, 0x70, 0x6b, 0x67, 0x2f, 0x61, 0x64, 0x76, 0x65, 0x72, 0x74,
 	0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
@@ -611,44 +1799,94 @@ func file_api_advert_proto_rawDescGZIP() []byte {
 	return file_api_advert_proto_rawDescData
 }
 
-var file_api_advert_proto_msgTypes = make([]protoimpl.MessageInfo, 10)
+var file_api_advert_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_api_advert_proto_msgTypes = make([]protoimpl.MessageInfo, 23)
 var file_api_advert_proto_goTypes = []any{
-	(*AdvertEmpty)(nil),         // 0: AdvertEmpty
-	(*AdvertText)(nil),          // 1: AdvertText
-	(*GetAdvertIn)(nil),         // 2: GetAdvertIn
-	(*GetAdvertOut)(nil),        // 3: GetAdvertOut
-	(*GetAdvertsOut)(nil),       // 4: GetAdvertsOut
-	(*UserFilter)(nil),          // 5: UserFilter
-	(*CreateAdvertIn)(nil),      // 6: CreateAdvertIn
-	(*CancelAdvertIn)(nil),      // 7: CancelAdvertIn
-	(*RestoreAdvertIn)(nil),     // 8: RestoreAdvertIn
-	(*EditAdvertIn)(nil),        // 9: EditAdvertIn
-	(*timestamp.Timestamp)(nil), // 10: google.protobuf.Timestamp
+	(AdvertStatus)(0),              // 0: AdvertStatus
+	(AdvertSortKey)(0),             // 1: AdvertSortKey
+	(*AdvertEmpty)(nil),            // 2: AdvertEmpty
+	(*AdvertText)(nil),             // 3: AdvertText
+	(*AttributeValue)(nil),         // 4: AttributeValue
+	(*GetAdvertIn)(nil),            // 5: GetAdvertIn
+	(*GetAdvertOut)(nil),           // 6: GetAdvertOut
+	(*GetAdvertsIn)(nil),           // 7: GetAdvertsIn
+	(*GetAdvertsOut)(nil),          // 8: GetAdvertsOut
+	(*UserFilter)(nil),             // 9: UserFilter
+	(*UserAttributes)(nil),         // 10: UserAttributes
+	(*GetAdvertsForUserIn)(nil),    // 11: GetAdvertsForUserIn
+	(*CreateAdvertIn)(nil),         // 12: CreateAdvertIn
+	(*CancelAdvertIn)(nil),         // 13: CancelAdvertIn
+	(*RestoreAdvertIn)(nil),        // 14: RestoreAdvertIn
+	(*EditAdvertIn)(nil),           // 15: EditAdvertIn
+	(*BanAdvertIn)(nil),            // 16: BanAdvertIn
+	(*UnbanAdvertIn)(nil),          // 17: UnbanAdvertIn
+	(*AdvertRevision)(nil),         // 18: AdvertRevision
+	(*ListAdvertRevisionsIn)(nil),  // 19: ListAdvertRevisionsIn
+	(*ListAdvertRevisionsOut)(nil), // 20: ListAdvertRevisionsOut
+	(*RevertAdvertIn)(nil),         // 21: RevertAdvertIn
+	(*PauseAdvertIn)(nil),          // 22: PauseAdvertIn
+	(*ResumeAdvertIn)(nil),         // 23: ResumeAdvertIn
+	(*ExtendAdvertIn)(nil),         // 24: ExtendAdvertIn
+	(*timestamp.Timestamp)(nil),    // 25: google.protobuf.Timestamp
+	(*fieldmaskpb.FieldMask)(nil),  // 26: google.protobuf.FieldMask
+	(*duration.Duration)(nil),      // 27: google.protobuf.Duration
 }
 var file_api_advert_proto_depIdxs = []int32{
-	10, // 0: AdvertText.expired_at:type_name -> google.protobuf.Timestamp
-	1,  // 1: GetAdvertOut.advert:type_name -> AdvertText
-	1,  // 2: GetAdvertsOut.adverts:type_name -> AdvertText
-	5,  // 3: CreateAdvertIn.user:type_name -> UserFilter
-	10, // 4: CreateAdvertIn.expired_at:type_name -> google.protobuf.Timestamp
-	5,  // 5: EditAdvertIn.user_filter:type_name -> UserFilter
-	2,  // 6: AdvertService.GetAdvert:input_type -> GetAdvertIn
-	0,  // 7: AdvertService.GetAdverts:input_type -> AdvertEmpty
-	6,  // 8: AdvertService.CreateAdvert:input_type -> CreateAdvertIn
-	7,  // 9: AdvertService.CancelAdvert:input_type -> CancelAdvertIn
-	8,  // 10: AdvertService.RestoreAdvert:input_type -> RestoreAdvertIn
-	9,  // 11: AdvertService.EditAdvert:input_type -> EditAdvertIn
-	3,  // 12: AdvertService.GetAdvert:output_type -> GetAdvertOut
-	4,  // 13: AdvertService.GetAdverts:output_type -> GetAdvertsOut
-	0,  // 14: AdvertService.CreateAdvert:output_type -> AdvertEmpty
-	0,  // 15: AdvertService.CancelAdvert:output_type -> AdvertEmpty
-	0,  // 16: AdvertService.RestoreAdvert:output_type -> AdvertEmpty
-	0,  // 17: AdvertService.EditAdvert:output_type -> AdvertEmpty
-	12, // [12:18] is the sub-list for method output_type
-	6,  // [6:12] is the sub-list for method input_type
-	6,  // [6:6] is the sub-list for extension type_name
-	6,  // [6:6] is the sub-list for extension extendee
-	0,  // [0:6] is the sub-list for field type_name
+	25, // 0: AdvertText.expired_at:type_name -> google.protobuf.Timestamp
+	0,  // 1: AdvertText.status:type_name -> AdvertStatus
+	25, // 2: AdvertText.start_at:type_name -> google.protobuf.Timestamp
+	4,  // 3: AdvertText.os:type_name -> AttributeValue
+	3,  // 4: GetAdvertOut.advert:type_name -> AdvertText
+	1,  // 5: GetAdvertsIn.sort_by:type_name -> AdvertSortKey
+	0,  // 6: GetAdvertsIn.statuses:type_name -> AdvertStatus
+	3,  // 7: GetAdvertsOut.adverts:type_name -> AdvertText
+	10, // 8: GetAdvertsForUserIn.user:type_name -> UserAttributes
+	9,  // 9: CreateAdvertIn.user:type_name -> UserFilter
+	25, // 10: CreateAdvertIn.expired_at:type_name -> google.protobuf.Timestamp
+	25, // 11: CreateAdvertIn.start_at:type_name -> google.protobuf.Timestamp
+	9,  // 12: EditAdvertIn.user_filter:type_name -> UserFilter
+	25, // 13: EditAdvertIn.expired_at:type_name -> google.protobuf.Timestamp
+	26, // 14: EditAdvertIn.update_mask:type_name -> google.protobuf.FieldMask
+	25, // 15: EditAdvertIn.start_at:type_name -> google.protobuf.Timestamp
+	9,  // 16: AdvertRevision.user_filter:type_name -> UserFilter
+	25, // 17: AdvertRevision.expired_at:type_name -> google.protobuf.Timestamp
+	25, // 18: AdvertRevision.created_at:type_name -> google.protobuf.Timestamp
+	18, // 19: ListAdvertRevisionsOut.revisions:type_name -> AdvertRevision
+	25, // 20: ExtendAdvertIn.expired_at:type_name -> google.protobuf.Timestamp
+	27, // 21: ExtendAdvertIn.duration:type_name -> google.protobuf.Duration
+	5,  // 22: AdvertService.GetAdvert:input_type -> GetAdvertIn
+	7,  // 23: AdvertService.GetAdverts:input_type -> GetAdvertsIn
+	11, // 24: AdvertService.GetAdvertsForUser:input_type -> GetAdvertsForUserIn
+	12, // 25: AdvertService.CreateAdvert:input_type -> CreateAdvertIn
+	13, // 26: AdvertService.CancelAdvert:input_type -> CancelAdvertIn
+	14, // 27: AdvertService.RestoreAdvert:input_type -> RestoreAdvertIn
+	15, // 28: AdvertService.EditAdvert:input_type -> EditAdvertIn
+	16, // 29: AdvertService.BanAdvert:input_type -> BanAdvertIn
+	17, // 30: AdvertService.UnbanAdvert:input_type -> UnbanAdvertIn
+	19, // 31: AdvertService.ListAdvertRevisions:input_type -> ListAdvertRevisionsIn
+	21, // 32: AdvertService.RevertAdvert:input_type -> RevertAdvertIn
+	22, // 33: AdvertService.PauseAdvert:input_type -> PauseAdvertIn
+	23, // 34: AdvertService.ResumeAdvert:input_type -> ResumeAdvertIn
+	24, // 35: AdvertService.ExtendAdvert:input_type -> ExtendAdvertIn
+	6,  // 36: AdvertService.GetAdvert:output_type -> GetAdvertOut
+	8,  // 37: AdvertService.GetAdverts:output_type -> GetAdvertsOut
+	8,  // 38: AdvertService.GetAdvertsForUser:output_type -> GetAdvertsOut
+	2,  // 39: AdvertService.CreateAdvert:output_type -> AdvertEmpty
+	2,  // 40: AdvertService.CancelAdvert:output_type -> AdvertEmpty
+	2,  // 41: AdvertService.RestoreAdvert:output_type -> AdvertEmpty
+	2,  // 42: AdvertService.EditAdvert:output_type -> AdvertEmpty
+	2,  // 43: AdvertService.BanAdvert:output_type -> AdvertEmpty
+	2,  // 44: AdvertService.UnbanAdvert:output_type -> AdvertEmpty
+	20, // 45: AdvertService.ListAdvertRevisions:output_type -> ListAdvertRevisionsOut
+	2,  // 46: AdvertService.RevertAdvert:output_type -> AdvertEmpty
+	2,  // 47: AdvertService.PauseAdvert:output_type -> AdvertEmpty
+	2,  // 48: AdvertService.ResumeAdvert:output_type -> AdvertEmpty
+	2,  // 49: AdvertService.ExtendAdvert:output_type -> AdvertEmpty
+	36, // [36:50] is the sub-list for method output_type
+	22, // [22:36] is the sub-list for method input_type
+	22, // [22:22] is the sub-list for extension type_name
+	22, // [22:22] is the sub-list for extension extendee
+	0,  // [0:22] is the sub-list for field type_name
 }
 
 func init() { file_api_advert_proto_init() }
@@ -661,13 +1899,14 @@ func file_api_advert_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_api_advert_proto_rawDesc), len(file_api_advert_proto_rawDesc)),
-			NumEnums:      0,
-			NumMessages:   10,
+			NumEnums:      2,
+			NumMessages:   23,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_api_advert_proto_goTypes,
 		DependencyIndexes: file_api_advert_proto_depIdxs,
+		EnumInfos:         file_api_advert_proto_enumTypes,
 		MessageInfos:      file_api_advert_proto_msgTypes,
 	}.Build()
 	File_api_advert_proto = out.File
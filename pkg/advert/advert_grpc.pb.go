@@ -19,12 +19,20 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	AdvertService_GetAdvert_FullMethodName     = "/AdvertService/GetAdvert"
-	AdvertService_GetAdverts_FullMethodName    = "/AdvertService/GetAdverts"
-	AdvertService_CreateAdvert_FullMethodName  = "/AdvertService/CreateAdvert"
-	AdvertService_CancelAdvert_FullMethodName  = "/AdvertService/CancelAdvert"
-	AdvertService_RestoreAdvert_FullMethodName = "/AdvertService/RestoreAdvert"
-	AdvertService_EditAdvert_FullMethodName    = "/AdvertService/EditAdvert"
+	AdvertService_GetAdvert_FullMethodName           = "/AdvertService/GetAdvert"
+	AdvertService_GetAdverts_FullMethodName          = "/AdvertService/GetAdverts"
+	AdvertService_GetAdvertsForUser_FullMethodName   = "/AdvertService/GetAdvertsForUser"
+	AdvertService_CreateAdvert_FullMethodName        = "/AdvertService/CreateAdvert"
+	AdvertService_CancelAdvert_FullMethodName        = "/AdvertService/CancelAdvert"
+	AdvertService_RestoreAdvert_FullMethodName       = "/AdvertService/RestoreAdvert"
+	AdvertService_EditAdvert_FullMethodName          = "/AdvertService/EditAdvert"
+	AdvertService_BanAdvert_FullMethodName           = "/AdvertService/BanAdvert"
+	AdvertService_UnbanAdvert_FullMethodName         = "/AdvertService/UnbanAdvert"
+	AdvertService_ListAdvertRevisions_FullMethodName = "/AdvertService/ListAdvertRevisions"
+	AdvertService_RevertAdvert_FullMethodName        = "/AdvertService/RevertAdvert"
+	AdvertService_PauseAdvert_FullMethodName         = "/AdvertService/PauseAdvert"
+	AdvertService_ResumeAdvert_FullMethodName        = "/AdvertService/ResumeAdvert"
+	AdvertService_ExtendAdvert_FullMethodName        = "/AdvertService/ExtendAdvert"
 )
 
 // AdvertServiceClient is the client API for AdvertService service.
@@ -32,11 +40,19 @@ const (
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type AdvertServiceClient interface {
 	GetAdvert(ctx context.Context, in *GetAdvertIn, opts ...grpc.CallOption) (*GetAdvertOut, error)
-	GetAdverts(ctx context.Context, in *AdvertEmpty, opts ...grpc.CallOption) (*GetAdvertsOut, error)
+	GetAdverts(ctx context.Context, in *GetAdvertsIn, opts ...grpc.CallOption) (*GetAdvertsOut, error)
+	GetAdvertsForUser(ctx context.Context, in *GetAdvertsForUserIn, opts ...grpc.CallOption) (*GetAdvertsOut, error)
 	CreateAdvert(ctx context.Context, in *CreateAdvertIn, opts ...grpc.CallOption) (*AdvertEmpty, error)
 	CancelAdvert(ctx context.Context, in *CancelAdvertIn, opts ...grpc.CallOption) (*AdvertEmpty, error)
 	RestoreAdvert(ctx context.Context, in *RestoreAdvertIn, opts ...grpc.CallOption) (*AdvertEmpty, error)
 	EditAdvert(ctx context.Context, in *EditAdvertIn, opts ...grpc.CallOption) (*AdvertEmpty, error)
+	BanAdvert(ctx context.Context, in *BanAdvertIn, opts ...grpc.CallOption) (*AdvertEmpty, error)
+	UnbanAdvert(ctx context.Context, in *UnbanAdvertIn, opts ...grpc.CallOption) (*AdvertEmpty, error)
+	ListAdvertRevisions(ctx context.Context, in *ListAdvertRevisionsIn, opts ...grpc.CallOption) (*ListAdvertRevisionsOut, error)
+	RevertAdvert(ctx context.Context, in *RevertAdvertIn, opts ...grpc.CallOption) (*AdvertEmpty, error)
+	PauseAdvert(ctx context.Context, in *PauseAdvertIn, opts ...grpc.CallOption) (*AdvertEmpty, error)
+	ResumeAdvert(ctx context.Context, in *ResumeAdvertIn, opts ...grpc.CallOption) (*AdvertEmpty, error)
+	ExtendAdvert(ctx context.Context, in *ExtendAdvertIn, opts ...grpc.CallOption) (*AdvertEmpty, error)
 }
 
 type advertServiceClient struct {
@@ -57,7 +73,7 @@ func (c *advertServiceClient) GetAdvert(ctx context.Context, in *GetAdvertIn, op
 	return out, nil
 }
 
-func (c *advertServiceClient) GetAdverts(ctx context.Context, in *AdvertEmpty, opts ...grpc.CallOption) (*GetAdvertsOut, error) {
+func (c *advertServiceClient) GetAdverts(ctx context.Context, in *GetAdvertsIn, opts ...grpc.CallOption) (*GetAdvertsOut, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(GetAdvertsOut)
 	err := c.cc.Invoke(ctx, AdvertService_GetAdverts_FullMethodName, in, out, cOpts...)
@@ -67,6 +83,16 @@ func (c *advertServiceClient) GetAdverts(ctx context.Context, in *AdvertEmpty, o
 	return out, nil
 }
 
+func (c *advertServiceClient) GetAdvertsForUser(ctx context.Context, in *GetAdvertsForUserIn, opts ...grpc.CallOption) (*GetAdvertsOut, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetAdvertsOut)
+	err := c.cc.Invoke(ctx, AdvertService_GetAdvertsForUser_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *advertServiceClient) CreateAdvert(ctx context.Context, in *CreateAdvertIn, opts ...grpc.CallOption) (*AdvertEmpty, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(AdvertEmpty)
@@ -107,16 +133,94 @@ func (c *advertServiceClient) EditAdvert(ctx context.Context, in *EditAdvertIn,
 	return out, nil
 }
 
+func (c *advertServiceClient) BanAdvert(ctx context.Context, in *BanAdvertIn, opts ...grpc.CallOption) (*AdvertEmpty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AdvertEmpty)
+	err := c.cc.Invoke(ctx, AdvertService_BanAdvert_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *advertServiceClient) UnbanAdvert(ctx context.Context, in *UnbanAdvertIn, opts ...grpc.CallOption) (*AdvertEmpty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AdvertEmpty)
+	err := c.cc.Invoke(ctx, AdvertService_UnbanAdvert_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *advertServiceClient) ListAdvertRevisions(ctx context.Context, in *ListAdvertRevisionsIn, opts ...grpc.CallOption) (*ListAdvertRevisionsOut, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListAdvertRevisionsOut)
+	err := c.cc.Invoke(ctx, AdvertService_ListAdvertRevisions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *advertServiceClient) RevertAdvert(ctx context.Context, in *RevertAdvertIn, opts ...grpc.CallOption) (*AdvertEmpty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AdvertEmpty)
+	err := c.cc.Invoke(ctx, AdvertService_RevertAdvert_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *advertServiceClient) PauseAdvert(ctx context.Context, in *PauseAdvertIn, opts ...grpc.CallOption) (*AdvertEmpty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AdvertEmpty)
+	err := c.cc.Invoke(ctx, AdvertService_PauseAdvert_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *advertServiceClient) ResumeAdvert(ctx context.Context, in *ResumeAdvertIn, opts ...grpc.CallOption) (*AdvertEmpty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AdvertEmpty)
+	err := c.cc.Invoke(ctx, AdvertService_ResumeAdvert_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *advertServiceClient) ExtendAdvert(ctx context.Context, in *ExtendAdvertIn, opts ...grpc.CallOption) (*AdvertEmpty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AdvertEmpty)
+	err := c.cc.Invoke(ctx, AdvertService_ExtendAdvert_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // AdvertServiceServer is the server API for AdvertService service.
 // All implementations must embed UnimplementedAdvertServiceServer
 // for forward compatibility.
 type AdvertServiceServer interface {
 	GetAdvert(context.Context, *GetAdvertIn) (*GetAdvertOut, error)
-	GetAdverts(context.Context, *AdvertEmpty) (*GetAdvertsOut, error)
+	GetAdverts(context.Context, *GetAdvertsIn) (*GetAdvertsOut, error)
+	GetAdvertsForUser(context.Context, *GetAdvertsForUserIn) (*GetAdvertsOut, error)
 	CreateAdvert(context.Context, *CreateAdvertIn) (*AdvertEmpty, error)
 	CancelAdvert(context.Context, *CancelAdvertIn) (*AdvertEmpty, error)
 	RestoreAdvert(context.Context, *RestoreAdvertIn) (*AdvertEmpty, error)
 	EditAdvert(context.Context, *EditAdvertIn) (*AdvertEmpty, error)
+	BanAdvert(context.Context, *BanAdvertIn) (*AdvertEmpty, error)
+	UnbanAdvert(context.Context, *UnbanAdvertIn) (*AdvertEmpty, error)
+	ListAdvertRevisions(context.Context, *ListAdvertRevisionsIn) (*ListAdvertRevisionsOut, error)
+	RevertAdvert(context.Context, *RevertAdvertIn) (*AdvertEmpty, error)
+	PauseAdvert(context.Context, *PauseAdvertIn) (*AdvertEmpty, error)
+	ResumeAdvert(context.Context, *ResumeAdvertIn) (*AdvertEmpty, error)
+	ExtendAdvert(context.Context, *ExtendAdvertIn) (*AdvertEmpty, error)
 	mustEmbedUnimplementedAdvertServiceServer()
 }
 
@@ -130,9 +234,12 @@ type UnimplementedAdvertServiceServer struct{}
 func (UnimplementedAdvertServiceServer) GetAdvert(context.Context, *GetAdvertIn) (*GetAdvertOut, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetAdvert not implemented")
 }
-func (UnimplementedAdvertServiceServer) GetAdverts(context.Context, *AdvertEmpty) (*GetAdvertsOut, error) {
+func (UnimplementedAdvertServiceServer) GetAdverts(context.Context, *GetAdvertsIn) (*GetAdvertsOut, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetAdverts not implemented")
 }
+func (UnimplementedAdvertServiceServer) GetAdvertsForUser(context.Context, *GetAdvertsForUserIn) (*GetAdvertsOut, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAdvertsForUser not implemented")
+}
 func (UnimplementedAdvertServiceServer) CreateAdvert(context.Context, *CreateAdvertIn) (*AdvertEmpty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CreateAdvert not implemented")
 }
@@ -145,6 +252,27 @@ func (UnimplementedAdvertServiceServer) RestoreAdvert(context.Context, *RestoreA
 func (UnimplementedAdvertServiceServer) EditAdvert(context.Context, *EditAdvertIn) (*AdvertEmpty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method EditAdvert not implemented")
 }
+func (UnimplementedAdvertServiceServer) BanAdvert(context.Context, *BanAdvertIn) (*AdvertEmpty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BanAdvert not implemented")
+}
+func (UnimplementedAdvertServiceServer) UnbanAdvert(context.Context, *UnbanAdvertIn) (*AdvertEmpty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UnbanAdvert not implemented")
+}
+func (UnimplementedAdvertServiceServer) ListAdvertRevisions(context.Context, *ListAdvertRevisionsIn) (*ListAdvertRevisionsOut, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListAdvertRevisions not implemented")
+}
+func (UnimplementedAdvertServiceServer) RevertAdvert(context.Context, *RevertAdvertIn) (*AdvertEmpty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RevertAdvert not implemented")
+}
+func (UnimplementedAdvertServiceServer) PauseAdvert(context.Context, *PauseAdvertIn) (*AdvertEmpty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PauseAdvert not implemented")
+}
+func (UnimplementedAdvertServiceServer) ResumeAdvert(context.Context, *ResumeAdvertIn) (*AdvertEmpty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ResumeAdvert not implemented")
+}
+func (UnimplementedAdvertServiceServer) ExtendAdvert(context.Context, *ExtendAdvertIn) (*AdvertEmpty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExtendAdvert not implemented")
+}
 func (UnimplementedAdvertServiceServer) mustEmbedUnimplementedAdvertServiceServer() {}
 func (UnimplementedAdvertServiceServer) testEmbeddedByValue()                       {}
 
@@ -185,7 +313,7 @@ func _AdvertService_GetAdvert_Handler(srv interface{}, ctx context.Context, dec
 }
 
 func _AdvertService_GetAdverts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(AdvertEmpty)
+	in := new(GetAdvertsIn)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
@@ -197,7 +325,25 @@ func _AdvertService_GetAdverts_Handler(srv interface{}, ctx context.Context, dec
 		FullMethod: AdvertService_GetAdverts_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(AdvertServiceServer).GetAdverts(ctx, req.(*AdvertEmpty))
+		return srv.(AdvertServiceServer).GetAdverts(ctx, req.(*GetAdvertsIn))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdvertService_GetAdvertsForUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAdvertsForUserIn)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdvertServiceServer).GetAdvertsForUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdvertService_GetAdvertsForUser_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdvertServiceServer).GetAdvertsForUser(ctx, req.(*GetAdvertsForUserIn))
 	}
 	return interceptor(ctx, in, info, handler)
 }
@@ -274,6 +420,132 @@ func _AdvertService_EditAdvert_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _AdvertService_BanAdvert_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BanAdvertIn)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdvertServiceServer).BanAdvert(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdvertService_BanAdvert_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdvertServiceServer).BanAdvert(ctx, req.(*BanAdvertIn))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdvertService_UnbanAdvert_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnbanAdvertIn)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdvertServiceServer).UnbanAdvert(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdvertService_UnbanAdvert_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdvertServiceServer).UnbanAdvert(ctx, req.(*UnbanAdvertIn))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdvertService_ListAdvertRevisions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListAdvertRevisionsIn)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdvertServiceServer).ListAdvertRevisions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdvertService_ListAdvertRevisions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdvertServiceServer).ListAdvertRevisions(ctx, req.(*ListAdvertRevisionsIn))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdvertService_RevertAdvert_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevertAdvertIn)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdvertServiceServer).RevertAdvert(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdvertService_RevertAdvert_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdvertServiceServer).RevertAdvert(ctx, req.(*RevertAdvertIn))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdvertService_PauseAdvert_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PauseAdvertIn)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdvertServiceServer).PauseAdvert(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdvertService_PauseAdvert_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdvertServiceServer).PauseAdvert(ctx, req.(*PauseAdvertIn))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdvertService_ResumeAdvert_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResumeAdvertIn)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdvertServiceServer).ResumeAdvert(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdvertService_ResumeAdvert_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdvertServiceServer).ResumeAdvert(ctx, req.(*ResumeAdvertIn))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdvertService_ExtendAdvert_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExtendAdvertIn)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdvertServiceServer).ExtendAdvert(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdvertService_ExtendAdvert_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdvertServiceServer).ExtendAdvert(ctx, req.(*ExtendAdvertIn))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // AdvertService_ServiceDesc is the grpc.ServiceDesc for AdvertService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -289,6 +561,10 @@ var AdvertService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetAdverts",
 			Handler:    _AdvertService_GetAdverts_Handler,
 		},
+		{
+			MethodName: "GetAdvertsForUser",
+			Handler:    _AdvertService_GetAdvertsForUser_Handler,
+		},
 		{
 			MethodName: "CreateAdvert",
 			Handler:    _AdvertService_CreateAdvert_Handler,
@@ -305,6 +581,34 @@ var AdvertService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "EditAdvert",
 			Handler:    _AdvertService_EditAdvert_Handler,
 		},
+		{
+			MethodName: "BanAdvert",
+			Handler:    _AdvertService_BanAdvert_Handler,
+		},
+		{
+			MethodName: "UnbanAdvert",
+			Handler:    _AdvertService_UnbanAdvert_Handler,
+		},
+		{
+			MethodName: "ListAdvertRevisions",
+			Handler:    _AdvertService_ListAdvertRevisions_Handler,
+		},
+		{
+			MethodName: "RevertAdvert",
+			Handler:    _AdvertService_RevertAdvert_Handler,
+		},
+		{
+			MethodName: "PauseAdvert",
+			Handler:    _AdvertService_PauseAdvert_Handler,
+		},
+		{
+			MethodName: "ResumeAdvert",
+			Handler:    _AdvertService_ResumeAdvert_Handler,
+		},
+		{
+			MethodName: "ExtendAdvert",
+			Handler:    _AdvertService_ExtendAdvert_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "api/advert.proto",